@@ -0,0 +1,46 @@
+package batch
+
+import (
+	"context"
+
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+)
+
+// Encrypter is satisfied by the handler returned from encryption.Handler.
+type Encrypter interface {
+	Encrypt(req encryption.EncryptionRequest) (string, error)
+	EncryptContext(ctx context.Context, req encryption.EncryptionRequest) (string, error)
+}
+
+// Decrypter is satisfied by the handler returned from decryption.Handler.
+type Decrypter interface {
+	Decrypt(req decryption.DecryptionRequest) (string, error)
+	DecryptContext(ctx context.Context, req decryption.DecryptionRequest) (string, error)
+}
+
+// Result is written as one NDJSON line per input line. Line is the 1-based
+// input line number; Error and ErrorCode are set when the record failed.
+type Result struct {
+	Line          int    `json:"line"`
+	EncryptedData string `json:"encryptedData,omitempty"`
+	DecryptedData string `json:"decryptedData,omitempty"`
+	Error         string `json:"error,omitempty"`
+	ErrorCode     string `json:"errorCode,omitempty"`
+}
+
+// Summary counts the records processed by a stream.
+type Summary struct {
+	Total  int
+	Failed int
+}
+
+// Options tune stream processing.
+type Options struct {
+	// Workers is the number of records processed concurrently; defaults to
+	// runtime.NumCPU().
+	Workers int
+	// DecryptedBase64 base64 encodes decrypted data so binary payloads
+	// survive the JSON output.
+	DecryptedBase64 bool
+}
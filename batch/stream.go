@@ -0,0 +1,175 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// maxLineSize bounds a single NDJSON record.
+const maxLineSize = 64 << 20
+
+/* -------------------------------------------------------------------------- */
+/*                                EncryptStream                               */
+/* -------------------------------------------------------------------------- */
+// reads NDJSON EncryptionRequest records from r, encrypts them concurrently
+// and writes one Result per record to w in input order. Blank lines are
+// skipped; malformed or failing records produce a Result with Error set.
+func EncryptStream(ctx context.Context, r io.Reader, w io.Writer, handler Encrypter, opts Options) (Summary, error) {
+	return process(ctx, r, w, opts, func(line []byte) Result {
+		var req encryption.EncryptionRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			return errorResult(utils.WithKind(utils.ErrInvalidInput, err))
+		}
+		encryptedData, err := handler.EncryptContext(ctx, req)
+		if err != nil {
+			return errorResult(err)
+		}
+		return Result{EncryptedData: encryptedData}
+	})
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                DecryptStream                               */
+/* -------------------------------------------------------------------------- */
+// is the decryption counterpart of EncryptStream for DecryptionRequest records.
+func DecryptStream(ctx context.Context, r io.Reader, w io.Writer, handler Decrypter, opts Options) (Summary, error) {
+	return process(ctx, r, w, opts, func(line []byte) Result {
+		var req decryption.DecryptionRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			return errorResult(utils.WithKind(utils.ErrInvalidInput, err))
+		}
+		decryptedData, err := handler.DecryptContext(ctx, req)
+		if err != nil {
+			return errorResult(err)
+		}
+		if opts.DecryptedBase64 {
+			decryptedData = base64.StdEncoding.EncodeToString([]byte(decryptedData))
+		}
+		return Result{DecryptedData: decryptedData}
+	})
+}
+
+func errorResult(err error) Result {
+	return Result{Error: err.Error(), ErrorCode: utils.ErrorCode(err)}
+}
+
+type job struct {
+	line   int
+	data   []byte
+	result chan Result
+}
+
+// process fans records out to a worker pool and writes results in order.
+// At most 2*workers records are in flight, so memory stays bounded.
+func process(ctx context.Context, r io.Reader, w io.Writer, opts Options, fn func(line []byte) Result) (Summary, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan *job)
+	pending := make(chan *job, 2*workers)
+
+	// Workers
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				result := safeCall(fn, j.data)
+				result.Line = j.line
+				j.result <- result
+			}
+		}()
+	}
+
+	// Reader: hands out jobs and queues them for the writer in input order
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+		lineNumber := 0
+		for scanner.Scan() {
+			lineNumber++
+			data := scanner.Bytes()
+			if len(bytes.TrimSpace(data)) == 0 {
+				continue
+			}
+			j := &job{line: lineNumber, data: append([]byte(nil), data...), result: make(chan Result, 1)}
+			select {
+			case pending <- j:
+			case <-ctx.Done():
+				readErr <- ctx.Err()
+				return
+			}
+			select {
+			case jobs <- j:
+			case <-ctx.Done():
+				readErr <- ctx.Err()
+				return
+			}
+		}
+		readErr <- errors.Wrap(scanner.Err(), "[process][scanner.Scan]")
+	}()
+
+	// Writer
+	summary := Summary{}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	var writeErr error
+	for j := range pending {
+		var result Result
+		select {
+		case result = <-j.result:
+		case <-ctx.Done():
+			writeErr = ctx.Err()
+		}
+		if writeErr != nil {
+			break
+		}
+		summary.Total++
+		if result.Error != "" {
+			summary.Failed++
+		}
+		if err := encoder.Encode(result); err != nil {
+			writeErr = errors.Wrap(err, "[process][encoder.Encode]")
+			break
+		}
+	}
+	cancel()
+	for range pending {
+	}
+	wg.Wait()
+
+	if writeErr != nil {
+		return summary, writeErr
+	}
+	return summary, <-readErr
+}
+
+// safeCall keeps one bad record from taking down the whole stream.
+func safeCall(fn func(line []byte) Result, line []byte) (result Result) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = errorResult(fmt.Errorf("record processing panicked: %v", r))
+		}
+	}()
+	return fn(line)
+}
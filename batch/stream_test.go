@@ -0,0 +1,114 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+func readResults(t *testing.T, out *bytes.Buffer) []Result {
+	var results []Result
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		var result Result
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &result))
+		results = append(results, result)
+	}
+	return results
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         Tests for Encrypt/DecryptStream                    */
+/* -------------------------------------------------------------------------- */
+func TestEncryptDecryptStream(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyHandler := keypairgen.Handler(BC25519)
+	sender, err := keyHandler.Generate()
+	assert.NoError(t, err)
+	requester, err := keyHandler.Generate()
+	assert.NoError(t, err)
+
+	/* ------------------------------ Build Input ------------------------------- */
+	const records = 50
+	var in bytes.Buffer
+	encoder := json.NewEncoder(&in)
+	for i := 0; i < records; i++ {
+		encoder.Encode(encryption.EncryptionRequest{
+			StringToEncrypt:    fmt.Sprintf("record %d", i),
+			SenderNonce:        sender.Nonce,
+			RequesterNonce:     requester.Nonce,
+			SenderPrivateKey:   sender.PrivateKey,
+			RequesterPublicKey: requester.PublicKey,
+		})
+		if i == 10 {
+			in.WriteString("\n")
+			in.WriteString("{not json\n")
+		}
+	}
+
+	/* -------------------------------- Encrypt --------------------------------- */
+	var out bytes.Buffer
+	summary, err := EncryptStream(context.Background(), &in, &out, encryption.Handler(BC25519), Options{Workers: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, Summary{Total: records + 1, Failed: 1}, summary)
+
+	results := readResults(t, &out)
+	assert.Len(t, results, records+1)
+	assert.Equal(t, 13, results[11].Line)
+	assert.Equal(t, "INVALID_INPUT", results[11].ErrorCode)
+
+	/* -------------------------------- Decrypt --------------------------------- */
+	in.Reset()
+	for _, result := range results {
+		if result.Error != "" {
+			continue
+		}
+		encoder.Encode(decryption.DecryptionRequest{
+			EncryptedData:       result.EncryptedData,
+			SenderNonce:         sender.Nonce,
+			RequesterNonce:      requester.Nonce,
+			RequesterPrivateKey: requester.PrivateKey,
+			SenderPublicKey:     sender.PublicKey,
+		})
+	}
+	encoder.Encode(decryption.DecryptionRequest{
+		EncryptedData:       results[0].EncryptedData,
+		SenderNonce:         requester.Nonce,
+		RequesterNonce:      requester.Nonce,
+		RequesterPrivateKey: requester.PrivateKey,
+		SenderPublicKey:     sender.PublicKey,
+	})
+
+	out.Reset()
+	summary, err = DecryptStream(context.Background(), &in, &out, decryption.Handler(BC25519), Options{Workers: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, Summary{Total: records + 1, Failed: 1}, summary)
+	results = readResults(t, &out)
+	for i := 0; i < records; i++ {
+		assert.Equal(t, i+1, results[i].Line)
+		assert.Equal(t, fmt.Sprintf("record %d", i), results[i].DecryptedData)
+	}
+	assert.Equal(t, "DECRYPTION_FAILED", results[records].ErrorCode)
+}
+
+func TestStreamCancelled(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	in := strings.NewReader(strings.Repeat("{}\n", 100))
+	_, err = EncryptStream(ctx, in, &bytes.Buffer{}, encryption.Handler(BC25519), Options{Workers: 2})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zoop/fidelius-go/batch"
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// batchError reports records that failed in an otherwise complete batch.
+type batchError struct {
+	summary batch.Summary
+}
+
+func (e *batchError) Error() string {
+	return fmt.Sprintf("%d of %d records failed", e.summary.Failed, e.summary.Total)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                        batch-encrypt / batch-decrypt                       */
+/* -------------------------------------------------------------------------- */
+// processes newline-delimited JSON requests and writes NDJSON results.
+func runBatch(decrypt bool) command {
+	return func(args []string, stdin io.Reader, stdout io.Writer) error {
+		name := "batch-encrypt"
+		if decrypt {
+			name = "batch-decrypt"
+		}
+		fs, out := newFlagSet(name)
+		in := fs.String("in", "-", "read NDJSON requests from `file` (- for stdin)")
+		workers := fs.Int("workers", 0, "number of concurrent workers (default: number of CPUs)")
+		sane := fs.Bool("sane", false, "base64 encode decrypted data (batch-decrypt)")
+		if err := parseFlags(fs, args, 0); err != nil {
+			return err
+		}
+
+		r := stdin
+		if *in != "-" {
+			f, err := os.Open(*in)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		w := stdout
+		if *out != "" && *out != "-" {
+			f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		curve, err := utils.GetBC25519Curve()
+		if err != nil {
+			return err
+		}
+		opts := batch.Options{Workers: *workers, DecryptedBase64: *sane}
+		var summary batch.Summary
+		if decrypt {
+			summary, err = batch.DecryptStream(context.Background(), r, w, decryption.Handler(curve), opts)
+		} else {
+			summary, err = batch.EncryptStream(context.Background(), r, w, encryption.Handler(curve), opts)
+		}
+		if err != nil {
+			return err
+		}
+		if summary.Failed > 0 {
+			return &batchError{summary: summary}
+		}
+		return nil
+	}
+}
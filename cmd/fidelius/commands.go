@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/base64"
+	"io"
+
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+type encryptionResponse struct {
+	EncryptedData string `json:"encryptedData"`
+}
+
+type decryptionResponse struct {
+	DecryptedData string `json:"decryptedData"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                gen-ecdh-keys                               */
+/* -------------------------------------------------------------------------- */
+func runGenerate(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs, out := newFlagSet("gen-ecdh-keys")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+	curve, err := utils.GetBC25519Curve()
+	if err != nil {
+		return err
+	}
+	keyMaterial, err := keypairgen.Handler(curve).Generate()
+	if err != nil {
+		return err
+	}
+	return writeJSON(*out, stdout, keyMaterial)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           encrypt / sane-encrypt                           */
+/* -------------------------------------------------------------------------- */
+// sane mode takes the plaintext base64 encoded so binary data survives.
+func runEncrypt(sane bool) command {
+	return func(args []string, stdin io.Reader, stdout io.Writer) error {
+		name := "encrypt"
+		if sane {
+			name = "sane-encrypt"
+		}
+		fs, out := newFlagSet(name)
+		in := fs.String("in", "", "read the request as JSON from `file` (- for stdin)")
+		data := fs.String("data", "", "data to encrypt (base64 for sane-encrypt)")
+		senderNonce := fs.String("sender-nonce", "", "sender nonce (base64)")
+		requesterNonce := fs.String("requester-nonce", "", "requester nonce (base64)")
+		senderPrivateKey := fs.String("sender-private-key", "", "sender private key (base64)")
+		requesterPublicKey := fs.String("requester-public-key", "", "requester public key (base64)")
+		if err := parseFlags(fs, args, 5); err != nil {
+			return err
+		}
+
+		req := encryption.EncryptionRequest{}
+		if err := readJSON(*in, stdin, &req); err != nil {
+			return err
+		}
+		positional := fs.Args()
+		setString(&req.StringToEncrypt, *data, positional, 0)
+		setString(&req.SenderNonce, *senderNonce, positional, 1)
+		setString(&req.RequesterNonce, *requesterNonce, positional, 2)
+		setString(&req.SenderPrivateKey, *senderPrivateKey, positional, 3)
+		setString(&req.RequesterPublicKey, *requesterPublicKey, positional, 4)
+		if sane && req.StringToEncryptBase64 == nil {
+			req.StringToEncryptBase64 = &req.StringToEncrypt
+		}
+		if err := requireFields(map[string]string{
+			"sender nonce":         req.SenderNonce,
+			"requester nonce":      req.RequesterNonce,
+			"sender private key":   req.SenderPrivateKey,
+			"requester public key": req.RequesterPublicKey,
+		}); err != nil {
+			return err
+		}
+
+		curve, err := utils.GetBC25519Curve()
+		if err != nil {
+			return err
+		}
+		encryptedData, err := encryption.Handler(curve).Encrypt(req)
+		if err != nil {
+			return err
+		}
+		return writeJSON(*out, stdout, encryptionResponse{EncryptedData: encryptedData})
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           decrypt / sane-decrypt                           */
+/* -------------------------------------------------------------------------- */
+// sane mode returns the plaintext base64 encoded so binary data survives.
+func runDecrypt(sane bool) command {
+	return func(args []string, stdin io.Reader, stdout io.Writer) error {
+		name := "decrypt"
+		if sane {
+			name = "sane-decrypt"
+		}
+		fs, out := newFlagSet(name)
+		in := fs.String("in", "", "read the request as JSON from `file` (- for stdin)")
+		data := fs.String("data", "", "encrypted data (base64)")
+		requesterNonce := fs.String("requester-nonce", "", "requester nonce (base64)")
+		senderNonce := fs.String("sender-nonce", "", "sender nonce (base64)")
+		requesterPrivateKey := fs.String("requester-private-key", "", "requester private key (base64)")
+		senderPublicKey := fs.String("sender-public-key", "", "sender public key (base64)")
+		if err := parseFlags(fs, args, 5); err != nil {
+			return err
+		}
+
+		req := decryption.DecryptionRequest{}
+		if err := readJSON(*in, stdin, &req); err != nil {
+			return err
+		}
+		positional := fs.Args()
+		setString(&req.EncryptedData, *data, positional, 0)
+		setString(&req.RequesterNonce, *requesterNonce, positional, 1)
+		setString(&req.SenderNonce, *senderNonce, positional, 2)
+		setString(&req.RequesterPrivateKey, *requesterPrivateKey, positional, 3)
+		setString(&req.SenderPublicKey, *senderPublicKey, positional, 4)
+		if err := requireFields(map[string]string{
+			"encrypted data":        req.EncryptedData,
+			"requester nonce":       req.RequesterNonce,
+			"sender nonce":          req.SenderNonce,
+			"requester private key": req.RequesterPrivateKey,
+			"sender public key":     req.SenderPublicKey,
+		}); err != nil {
+			return err
+		}
+
+		curve, err := utils.GetBC25519Curve()
+		if err != nil {
+			return err
+		}
+		decryptedData, err := decryption.Handler(curve).Decrypt(req)
+		if err != nil {
+			return err
+		}
+		if sane {
+			decryptedData = base64.StdEncoding.EncodeToString([]byte(decryptedData))
+		}
+		return writeJSON(*out, stdout, decryptionResponse{DecryptedData: decryptedData})
+	}
+}
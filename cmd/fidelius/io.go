@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// errHelp is returned when -h was requested; the usage has been printed.
+var errHelp = errors.New("help requested")
+
+// newFlagSet returns a flag set with the -out flag every command that prints
+// a JSON result shares.
+func newFlagSet(name string) (*flag.FlagSet, *string) {
+	fs := newBareFlagSet(name)
+	out := fs.String("out", "", "write the JSON result to `file` instead of stdout")
+	return fs, out
+}
+
+// newBareFlagSet returns a flag set without any shared flags.
+func newBareFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+// parseFlags parses args and checks at most maxArgs positional arguments remain.
+func parseFlags(fs *flag.FlagSet, args []string, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fs.SetOutput(os.Stderr)
+			fs.PrintDefaults()
+			return errHelp
+		}
+		return &usageError{msg: err.Error()}
+	}
+	if fs.NArg() > maxArgs {
+		return &usageError{msg: fmt.Sprintf("expected at most %d arguments, got %d", maxArgs, fs.NArg())}
+	}
+	return nil
+}
+
+// setString fills dst from the flag value or the positional argument at index,
+// flags taking precedence over positional arguments and both over JSON input.
+func setString(dst *string, flagValue string, positional []string, index int) {
+	switch {
+	case flagValue != "":
+		*dst = flagValue
+	case index < len(positional):
+		*dst = positional[index]
+	}
+}
+
+func requireFields(fields map[string]string) error {
+	var missing []string
+	for name, value := range fields {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return &usageError{msg: "missing " + strings.Join(missing, ", ")}
+}
+
+// readJSON decodes a JSON request from path; "-" reads stdin and "" is a no-op.
+func readJSON(path string, stdin io.Reader, v any) error {
+	if path == "" {
+		return nil
+	}
+	r := stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return &usageError{msg: "invalid JSON request: " + err.Error()}
+	}
+	return nil
+}
+
+// writeJSON writes v as JSON to path, or to stdout when path is empty or "-".
+// A failure to close the file is reported, since the result may not have been
+// written completely.
+func writeJSON(path string, stdout io.Writer, v any) (err error) {
+	w := stdout
+	if path != "" && path != "-" {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = f
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}
@@ -0,0 +1,116 @@
+// Command fidelius generates ECDH key material and encrypts or decrypts
+// ABDM Fidelius payloads. Its subcommands mirror the Java fidelius-cli.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// Exit codes
+const (
+	exitOK               = 0
+	exitFailure          = 1
+	exitUsage            = 2
+	exitInvalidInput     = 3
+	exitInvalidKey       = 4
+	exitDecryptionFailed = 5
+	exitBatchErrors      = 6
+)
+
+const usage = `usage: fidelius <command> [flags] [args]
+
+commands:
+  gen-ecdh-keys                    generate a key pair and nonce
+  encrypt, e       <data> <senderNonce> <requesterNonce> <senderPrivateKey> <requesterPublicKey>
+  sane-encrypt, se <base64Data> <senderNonce> <requesterNonce> <senderPrivateKey> <requesterPublicKey>
+  decrypt, d       <encryptedData> <requesterNonce> <senderNonce> <requesterPrivateKey> <senderPublicKey>
+  sane-decrypt, sd <encryptedData> <requesterNonce> <senderNonce> <requesterPrivateKey> <senderPublicKey>
+  batch-encrypt                    encrypt NDJSON requests from stdin, one result per line
+  batch-decrypt                    decrypt NDJSON requests from stdin, one result per line
+  serve                            serve /keys, /encrypt and /decrypt over HTTP
+
+Arguments may instead be given as flags or as a JSON request with -in
+(use "-in -" for stdin). Run "fidelius <command> -h" for the flags.
+
+exit status: 0 ok, 1 failure, 2 usage, 3 invalid input, 4 invalid key,
+5 decryption failed, 6 some batch records failed
+`
+
+// usageError marks command line mistakes.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+type command func(args []string, stdin io.Reader, stdout io.Writer) error
+
+var commands = map[string]command{
+	"gen-ecdh-keys": runGenerate,
+	"encrypt":       runEncrypt(false),
+	"e":             runEncrypt(false),
+	"sane-encrypt":  runEncrypt(true),
+	"se":            runEncrypt(true),
+	"decrypt":       runDecrypt(false),
+	"d":             runDecrypt(false),
+	"sane-decrypt":  runDecrypt(true),
+	"sd":            runDecrypt(true),
+	"batch-encrypt": runBatch(false),
+	"batch-decrypt": runBatch(true),
+	"serve":         runServe,
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    main                                    */
+/* -------------------------------------------------------------------------- */
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes one subcommand and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "help" {
+		fmt.Fprint(stderr, usage)
+		if len(args) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "fidelius: unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
+	}
+	if err := cmd(args[1:], stdin, stdout); err != nil {
+		if errors.Is(err, errHelp) {
+			return exitOK
+		}
+		fmt.Fprintf(stderr, "fidelius %s: %v\n", args[0], err)
+		return exitCode(err)
+	}
+	return exitOK
+}
+
+func exitCode(err error) int {
+	var usageErr *usageError
+	var batchErr *batchError
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.As(err, &batchErr):
+		return exitBatchErrors
+	case errors.Is(err, utils.ErrInvalidInput):
+		return exitInvalidInput
+	case errors.Is(err, utils.ErrInvalidKey):
+		return exitInvalidKey
+	case errors.Is(err, utils.ErrDecryptionFailed):
+		return exitDecryptionFailed
+	default:
+		return exitFailure
+	}
+}
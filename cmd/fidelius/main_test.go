@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/httpapi"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+func runCLI(t *testing.T, stdin string, args ...string) (int, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return code, stdout.String()
+}
+
+func generateKeys(t *testing.T) keypairgen.KeyMaterial {
+	code, out := runCLI(t, "", "gen-ecdh-keys")
+	assert.Equal(t, exitOK, code)
+	var keyMaterial keypairgen.KeyMaterial
+	assert.NoError(t, json.Unmarshal([]byte(out), &keyMaterial))
+	assert.NotEmpty(t, keyMaterial.PrivateKey)
+	return keyMaterial
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                Tests for CLI                               */
+/* -------------------------------------------------------------------------- */
+func TestEncryptDecrypt(t *testing.T) {
+	sender := generateKeys(t)
+	requester := generateKeys(t)
+
+	code, out := runCLI(t, "", "e", "Hello, World!", sender.Nonce, requester.Nonce, sender.PrivateKey, requester.PublicKey)
+	assert.Equal(t, exitOK, code)
+	var encrypted encryptionResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &encrypted))
+
+	// flags and positional arguments can be mixed
+	code, out = runCLI(t, "", "decrypt",
+		"-requester-private-key", requester.PrivateKey,
+		encrypted.EncryptedData, requester.Nonce, sender.Nonce, "", sender.PublicKey)
+	assert.Equal(t, exitOK, code)
+	assert.JSONEq(t, `{"decryptedData":"Hello, World!"}`, out)
+}
+
+func TestSaneEncryptDecryptFromJSON(t *testing.T) {
+	sender := generateKeys(t)
+	requester := generateKeys(t)
+	binary := base64.StdEncoding.EncodeToString([]byte{0x00, 0xff, 0x10, 0x80})
+
+	request, _ := json.Marshal(map[string]string{
+		"stringToEncrypt":    binary,
+		"senderNonce":        sender.Nonce,
+		"requesterNonce":     requester.Nonce,
+		"senderPrivateKey":   sender.PrivateKey,
+		"requesterPublicKey": requester.PublicKey,
+	})
+	outPath := filepath.Join(t.TempDir(), "encrypted.json")
+	code, _ := runCLI(t, string(request), "se", "-in", "-", "-out", outPath)
+	assert.Equal(t, exitOK, code)
+
+	code, out := runCLI(t, "", "sd", "-in", outPath,
+		"-requester-nonce", requester.Nonce,
+		"-sender-nonce", sender.Nonce,
+		"-requester-private-key", requester.PrivateKey,
+		"-sender-public-key", sender.PublicKey)
+	assert.Equal(t, exitOK, code)
+	assert.JSONEq(t, `{"decryptedData":"`+binary+`"}`, out)
+}
+
+func TestExitCodes(t *testing.T) {
+	sender := generateKeys(t)
+	requester := generateKeys(t)
+	code, out := runCLI(t, "", "e", "Hello, World!", sender.Nonce, requester.Nonce, sender.PrivateKey, requester.PublicKey)
+	assert.Equal(t, exitOK, code)
+	var encrypted encryptionResponse
+	assert.NoError(t, json.Unmarshal([]byte(out), &encrypted))
+	offCurve := base64.StdEncoding.EncodeToString(append([]byte{0x04}, bytes.Repeat([]byte{0x01}, 64)...))
+
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"no command", nil, exitUsage},
+		{"unknown command", []string{"frobnicate"}, exitUsage},
+		{"unexpected argument", []string{"gen-ecdh-keys", "extra"}, exitUsage},
+		{"serve has no output file", []string{"serve", "-out", "result.json"}, exitUsage},
+		{"missing arguments", []string{"d", encrypted.EncryptedData}, exitUsage},
+		{"bad nonce", []string{"e", "data", "not base64", requester.Nonce, sender.PrivateKey, requester.PublicKey}, exitInvalidInput},
+		{"bad public key", []string{"e", "data", sender.Nonce, requester.Nonce, sender.PrivateKey, offCurve}, exitInvalidKey},
+		{"wrong nonce", []string{"d", encrypted.EncryptedData, sender.Nonce, sender.Nonce, requester.PrivateKey, sender.PublicKey}, exitDecryptionFailed},
+	}
+	if _, err := os.Stat("/dev/full"); err == nil {
+		tests = append(tests, struct {
+			name string
+			args []string
+			code int
+		}{"output file not written", []string{"gen-ecdh-keys", "-out", "/dev/full"}, exitFailure})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := runCLI(t, "", tt.args...)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestBatchEncryptDecrypt(t *testing.T) {
+	sender := generateKeys(t)
+	requester := generateKeys(t)
+
+	var in strings.Builder
+	for _, data := range []string{"first", "second", "third"} {
+		line, _ := json.Marshal(map[string]string{
+			"stringToEncrypt":    data,
+			"senderNonce":        sender.Nonce,
+			"requesterNonce":     requester.Nonce,
+			"senderPrivateKey":   sender.PrivateKey,
+			"requesterPublicKey": requester.PublicKey,
+		})
+		in.Write(append(line, '\n'))
+	}
+	code, out := runCLI(t, in.String(), "batch-encrypt", "-workers", "2")
+	assert.Equal(t, exitOK, code)
+
+	var decryptIn strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var result struct{ EncryptedData string }
+		assert.NoError(t, json.Unmarshal([]byte(line), &result))
+		request, _ := json.Marshal(map[string]string{
+			"encryptedData":       result.EncryptedData,
+			"requesterNonce":      requester.Nonce,
+			"senderNonce":         sender.Nonce,
+			"requesterPrivateKey": requester.PrivateKey,
+			"senderPublicKey":     sender.PublicKey,
+		})
+		decryptIn.Write(append(request, '\n'))
+	}
+	bad, _ := json.Marshal(map[string]string{
+		"encryptedData":       "not base64",
+		"requesterNonce":      requester.Nonce,
+		"senderNonce":         sender.Nonce,
+		"requesterPrivateKey": requester.PrivateKey,
+		"senderPublicKey":     sender.PublicKey,
+	})
+	decryptIn.Write(append(bad, '\n'))
+
+	code, out = runCLI(t, decryptIn.String(), "batch-decrypt")
+	assert.Equal(t, exitBatchErrors, code)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	assert.Len(t, lines, 4)
+	assert.JSONEq(t, `{"line":2,"decryptedData":"second"}`, lines[1])
+	assert.Contains(t, lines[3], `"errorCode":"INVALID_INPUT"`)
+}
+
+func TestServe(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- serve(ctx, &http.Server{Handler: httpapi.Handler(BC25519)}, ln)
+	}()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/healthz")
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
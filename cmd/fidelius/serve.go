@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/zoop/fidelius-go/httpapi"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+/* -------------------------------------------------------------------------- */
+/*                                    serve                                   */
+/* -------------------------------------------------------------------------- */
+// serves the HTTP API until interrupted.
+func runServe(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := newBareFlagSet("serve")
+	addr := fs.String("addr", "127.0.0.1:8080", "listen `address`")
+	maxBodySize := fs.Int64("max-body-size", httpapi.DefaultMaxBodySize, "maximum request body size in `bytes`")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	curve, err := utils.GetBC25519Curve()
+	if err != nil {
+		return err
+	}
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:           httpapi.Handler(curve, httpapi.WithMaxBodySize(*maxBodySize)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(stdout, "listening on http://%s\n", ln.Addr())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return serve(ctx, server, ln)
+}
+
+// serve runs server on ln until ctx is done, then shuts it down gracefully.
+func serve(ctx context.Context, server *http.Server, ln net.Listener) error {
+	errc := make(chan error, 1)
+	go func() { errc <- server.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+// Command libfidelius exports key generation, encryption and decryption to C.
+// Build it with
+//
+//	go build -buildmode=c-shared -o libfidelius.so ./cmd/libfidelius
+//
+// which also writes the libfidelius.h header. Every function returns a
+// fidelius_status. Output strings and buffers are allocated with malloc and
+// belong to the caller, who releases them with fidelius_free (or
+// fidelius_free_secret for decrypted data). On failure no outputs are set
+// and, when errMsg is not NULL, *errMsg receives an error message the
+// caller also frees.
+package main
+
+/*
+#include <stdlib.h>
+#include <string.h>
+
+typedef enum {
+	FIDELIUS_OK = 0,
+	FIDELIUS_INVALID_INPUT = 1,
+	FIDELIUS_INVALID_KEY = 2,
+	FIDELIUS_DECRYPTION_FAILED = 3,
+	FIDELIUS_INTERNAL = 4
+} fidelius_status;
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+func main() {}
+
+/* -------------------------------------------------------------------------- */
+/*                       fidelius_generate_key_material                       */
+/* -------------------------------------------------------------------------- */
+//export fidelius_generate_key_material
+func fidelius_generate_key_material(privateKey, publicKey, x509PublicKey, nonce **C.char, errMsg **C.char) (status C.fidelius_status) {
+	defer recoverStatus(&status, errMsg)
+
+	if privateKey == nil || publicKey == nil || x509PublicKey == nil || nonce == nil {
+		return fail(utils.WithKind(utils.ErrInvalidInput, errors.New("output pointer is NULL")), errMsg)
+	}
+	curve, err := utils.GetBC25519Curve()
+	if err != nil {
+		return fail(err, errMsg)
+	}
+	keyMaterial, err := keypairgen.Handler(curve).Generate()
+	if err != nil {
+		return fail(err, errMsg)
+	}
+	*privateKey = C.CString(keyMaterial.PrivateKey)
+	*publicKey = C.CString(keyMaterial.PublicKey)
+	*x509PublicKey = C.CString(keyMaterial.X509PublicKey)
+	*nonce = C.CString(keyMaterial.Nonce)
+	return C.FIDELIUS_OK
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              fidelius_encrypt                              */
+/* -------------------------------------------------------------------------- */
+// encrypts dataLen bytes of data, which may contain NUL bytes. dataLen is
+// limited to INT_MAX.
+//
+//export fidelius_encrypt
+func fidelius_encrypt(senderNonce, requesterNonce, senderPrivateKey, requesterPublicKey *C.char, data *C.uchar, dataLen C.size_t, encryptedData **C.char, errMsg **C.char) (status C.fidelius_status) {
+	defer recoverStatus(&status, errMsg)
+
+	if encryptedData == nil || (data == nil && dataLen > 0) {
+		return fail(utils.WithKind(utils.ErrInvalidInput, errors.New("NULL argument")), errMsg)
+	}
+	// C.GoBytes takes a C int, which a larger size_t would overflow
+	if dataLen > math.MaxInt32 {
+		return fail(utils.WithKind(utils.ErrInvalidInput, errors.New("data too large")), errMsg)
+	}
+	curve, err := utils.GetBC25519Curve()
+	if err != nil {
+		return fail(err, errMsg)
+	}
+	plaintext := C.GoBytes(unsafe.Pointer(data), C.int(dataLen))
+	defer utils.Zeroize(plaintext)
+	plaintextBase64 := utils.EncodeBase64(plaintext)
+
+	result, err := encryption.Handler(curve).Encrypt(encryption.EncryptionRequest{
+		SenderNonce:           goString(senderNonce),
+		RequesterNonce:        goString(requesterNonce),
+		SenderPrivateKey:      goString(senderPrivateKey),
+		RequesterPublicKey:    goString(requesterPublicKey),
+		StringToEncryptBase64: &plaintextBase64,
+	})
+	if err != nil {
+		return fail(err, errMsg)
+	}
+	*encryptedData = C.CString(result)
+	return C.FIDELIUS_OK
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              fidelius_decrypt                              */
+/* -------------------------------------------------------------------------- */
+// decrypts encryptedData into a malloc'd buffer of *dataLen bytes. The
+// buffer is NUL-terminated for convenience; the terminator isn't counted.
+//
+//export fidelius_decrypt
+func fidelius_decrypt(senderNonce, requesterNonce, requesterPrivateKey, senderPublicKey, encryptedData *C.char, data **C.uchar, dataLen *C.size_t, errMsg **C.char) (status C.fidelius_status) {
+	defer recoverStatus(&status, errMsg)
+
+	if data == nil || dataLen == nil {
+		return fail(utils.WithKind(utils.ErrInvalidInput, errors.New("output pointer is NULL")), errMsg)
+	}
+	curve, err := utils.GetBC25519Curve()
+	if err != nil {
+		return fail(err, errMsg)
+	}
+	plaintext, err := decryption.Handler(curve).Decrypt(decryption.DecryptionRequest{
+		SenderNonce:         goString(senderNonce),
+		RequesterNonce:      goString(requesterNonce),
+		RequesterPrivateKey: goString(requesterPrivateKey),
+		SenderPublicKey:     goString(senderPublicKey),
+		EncryptedData:       goString(encryptedData),
+	})
+	if err != nil {
+		return fail(err, errMsg)
+	}
+	buf := (*C.uchar)(C.malloc(C.size_t(len(plaintext) + 1)))
+	if buf == nil {
+		return fail(errors.New("out of memory"), errMsg)
+	}
+	out := unsafe.Slice((*byte)(unsafe.Pointer(buf)), len(plaintext)+1)
+	copy(out, plaintext)
+	out[len(plaintext)] = 0
+	*data = buf
+	*dataLen = C.size_t(len(plaintext))
+	return C.FIDELIUS_OK
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                fidelius_free                               */
+/* -------------------------------------------------------------------------- */
+// releases a string or buffer returned by this library. NULL is ignored.
+//
+//export fidelius_free
+func fidelius_free(p unsafe.Pointer) {
+	C.free(p)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            fidelius_free_secret                            */
+/* -------------------------------------------------------------------------- */
+// wipes n bytes of p before releasing it. Use it for decrypted data.
+//
+//export fidelius_free_secret
+func fidelius_free_secret(p unsafe.Pointer, n C.size_t) {
+	if p == nil {
+		return
+	}
+	C.memset(p, 0, n)
+	C.free(p)
+}
+
+func goString(s *C.char) string {
+	if s == nil {
+		return ""
+	}
+	return C.GoString(s)
+}
+
+// fail reports err through errMsg and returns its status code.
+func fail(err error, errMsg **C.char) C.fidelius_status {
+	if errMsg != nil {
+		*errMsg = C.CString(err.Error())
+	}
+	switch utils.KindOf(err) {
+	case utils.ErrInvalidInput:
+		return C.FIDELIUS_INVALID_INPUT
+	case utils.ErrInvalidKey:
+		return C.FIDELIUS_INVALID_KEY
+	case utils.ErrDecryptionFailed:
+		return C.FIDELIUS_DECRYPTION_FAILED
+	}
+	return C.FIDELIUS_INTERNAL
+}
+
+// recoverStatus keeps a Go panic from unwinding into the C caller.
+func recoverStatus(status *C.fidelius_status, errMsg **C.char) {
+	if r := recover(); r != nil {
+		*status = fail(fmt.Errorf("panic: %v", r), errMsg)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                            Tests for the C API                             */
+/* -------------------------------------------------------------------------- */
+// TestCProgram builds the shared library, compiles testdata/fidelius_test.c
+// against it and runs the result.
+func TestCProgram(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("C test program is only built on Linux")
+	}
+	if testing.Short() {
+		t.Skip("skipping shared library build in short mode")
+	}
+	cc, err := exec.LookPath("gcc")
+	if err != nil {
+		t.Skip("gcc not found")
+	}
+
+	dir := t.TempDir()
+	build := exec.Command("go", "build", "-buildmode=c-shared", "-o", filepath.Join(dir, "libfidelius.so"), ".")
+	out, err := build.CombinedOutput()
+	if !assert.NoError(t, err, string(out)) {
+		return
+	}
+
+	src, err := filepath.Abs(filepath.Join("testdata", "fidelius_test.c"))
+	assert.NoError(t, err)
+	bin := filepath.Join(dir, "fidelius_test")
+	compile := exec.Command(cc, "-Wall", "-Werror", "-o", bin, src, "-I", dir, "-L", dir, "-lfidelius", "-Wl,-rpath,"+dir)
+	out, err = compile.CombinedOutput()
+	if !assert.NoError(t, err, string(out)) {
+		return
+	}
+
+	run := exec.Command(bin)
+	run.Env = append(os.Environ(), "LD_LIBRARY_PATH="+dir)
+	out, err = run.CombinedOutput()
+	assert.NoError(t, err, string(out))
+	assert.Equal(t, "ok\n", string(out))
+}
@@ -0,0 +1,94 @@
+/* Exercises libfidelius from C: key generation, an encrypt/decrypt round
+ * trip with embedded NUL bytes, and the error codes. Exits non-zero on the
+ * first failed check. */
+#include <limits.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "libfidelius.h"
+
+#define CHECK(cond)                                                     \
+	do {                                                                \
+		if (!(cond)) {                                                  \
+			fprintf(stderr, "%s:%d: check failed: %s\n", __FILE__,      \
+			        __LINE__, #cond);                                   \
+			return 1;                                                   \
+		}                                                               \
+	} while (0)
+
+typedef struct {
+	char *private_key, *public_key, *x509_public_key, *nonce;
+} key_material;
+
+static int generate(key_material *km) {
+	char *err = NULL;
+	fidelius_status status = fidelius_generate_key_material(
+	    &km->private_key, &km->public_key, &km->x509_public_key, &km->nonce, &err);
+	if (status != FIDELIUS_OK) {
+		fprintf(stderr, "generate: %d %s\n", status, err);
+		fidelius_free(err);
+	}
+	return status;
+}
+
+static void release(key_material *km) {
+	fidelius_free(km->private_key);
+	fidelius_free(km->public_key);
+	fidelius_free(km->x509_public_key);
+	fidelius_free(km->nonce);
+}
+
+int main(void) {
+	key_material sender, requester;
+	CHECK(generate(&sender) == FIDELIUS_OK);
+	CHECK(generate(&requester) == FIDELIUS_OK);
+
+	/* Round trip */
+	unsigned char message[] = {'H', 'i', 0, 'C', '!'};
+	char *encrypted = NULL, *err = NULL;
+	CHECK(fidelius_encrypt(sender.nonce, requester.nonce, sender.private_key,
+	                       requester.public_key, message, sizeof message,
+	                       &encrypted, &err) == FIDELIUS_OK);
+	CHECK(encrypted != NULL && err == NULL);
+
+	unsigned char *plaintext = NULL;
+	size_t plaintext_len = 0;
+	CHECK(fidelius_decrypt(sender.nonce, requester.nonce, requester.private_key,
+	                       sender.public_key, encrypted, &plaintext,
+	                       &plaintext_len, &err) == FIDELIUS_OK);
+	CHECK(plaintext_len == sizeof message);
+	CHECK(memcmp(plaintext, message, sizeof message) == 0);
+	fidelius_free_secret(plaintext, plaintext_len);
+
+	/* Wrong key */
+	plaintext = NULL;
+	CHECK(fidelius_decrypt(sender.nonce, requester.nonce, sender.private_key,
+	                       sender.public_key, encrypted, &plaintext,
+	                       &plaintext_len, &err) == FIDELIUS_DECRYPTION_FAILED);
+	CHECK(plaintext == NULL && err != NULL);
+	fidelius_free(err);
+	err = NULL;
+
+	/* Invalid key, with the error message ignored */
+	CHECK(fidelius_encrypt(sender.nonce, requester.nonce, sender.private_key,
+	                       "not base64", message, sizeof message, &encrypted,
+	                       NULL) == FIDELIUS_INVALID_KEY);
+
+	/* Lengths above INT_MAX are rejected before data is read */
+	CHECK(fidelius_encrypt(sender.nonce, requester.nonce, sender.private_key,
+	                       requester.public_key, message, (size_t)INT_MAX + 1,
+	                       &encrypted, NULL) == FIDELIUS_INVALID_INPUT);
+
+	/* Invalid input */
+	CHECK(fidelius_decrypt(sender.nonce, requester.nonce, requester.private_key,
+	                       sender.public_key, "not base64", &plaintext,
+	                       &plaintext_len, &err) == FIDELIUS_INVALID_INPUT);
+	fidelius_free(err);
+
+	fidelius_free(encrypted);
+	release(&sender);
+	release(&requester);
+	printf("ok\n");
+	return 0;
+}
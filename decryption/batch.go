@@ -0,0 +1,39 @@
+package decryption
+
+import (
+	"context"
+
+	"github.com/zoop/fidelius-go/internal/workpool"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                DecryptBatch                                */
+/* -------------------------------------------------------------------------- */
+// decrypts reqs on a pool of WithWorkers goroutines and returns one result
+// per request, in order. The ECDH shared secret is computed once for each
+// distinct RequesterPrivateKey and SenderPublicKey pair; requests with a
+// RequesterKey are not deduplicated. When ctx is done no further requests
+// are started and requests in flight stop as in DecryptContext: their
+// results carry ctx.Err(), which is also returned.
+func (cc *decryptionHandler) DecryptBatch(ctx context.Context, reqs []DecryptionRequest) ([]BatchResult, error) {
+	results := make([]BatchResult, len(reqs))
+	var secrets workpool.SecretCache
+	defer secrets.Destroy()
+
+	sharedSecret := func(req DecryptionRequest) (*utils.Secret, error) {
+		if req.RequesterKey != nil {
+			return cc.sharedSecret(req)
+		}
+		// Base64 never contains NUL, so the key is unambiguous
+		return secrets.Get(req.RequesterPrivateKey+"\x00"+req.SenderPublicKey, func() (*utils.Secret, error) {
+			return cc.sharedSecret(req)
+		})
+	}
+	err := workpool.Run(ctx, len(reqs), cc.Workers, func(i int) {
+		results[i].DecryptedData, results[i].Err = cc.decrypt(ctx, reqs[i], sharedSecret)
+	}, func(i int) {
+		results[i].Err = ctx.Err()
+	})
+	return results, err
+}
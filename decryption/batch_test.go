@@ -0,0 +1,68 @@
+package decryption
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                           Tests for DecryptBatch                           */
+/* -------------------------------------------------------------------------- */
+func TestDecryptBatch(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	generator := keypairgen.Handler(BC25519)
+	requester, err := generator.Generate()
+	assert.NoError(t, err)
+	var senders []*keypairgen.KeyMaterial
+	for i := 0; i < 2; i++ {
+		sender, err := generator.Generate()
+		assert.NoError(t, err)
+		senders = append(senders, sender)
+	}
+
+	var encryptionReqs []encryption.EncryptionRequest
+	for i := 0; i < 20; i++ {
+		sender := senders[i%len(senders)]
+		encryptionReqs = append(encryptionReqs, encryption.EncryptionRequest{
+			StringToEncrypt:    fmt.Sprintf("entry %d", i),
+			SenderNonce:        utils.GenerateBase64Nonce(),
+			RequesterNonce:     requester.Nonce,
+			SenderPrivateKey:   sender.PrivateKey,
+			RequesterPublicKey: requester.PublicKey,
+		})
+	}
+	encrypted, err := encryption.Handler(BC25519).EncryptBatch(context.Background(), encryptionReqs)
+	assert.NoError(t, err)
+
+	reqs := make([]DecryptionRequest, len(encryptionReqs))
+	for i, req := range encryptionReqs {
+		assert.NoError(t, encrypted[i].Err)
+		reqs[i] = DecryptionRequest{
+			EncryptedData:       encrypted[i].EncryptedData,
+			SenderNonce:         req.SenderNonce,
+			RequesterNonce:      requester.Nonce,
+			RequesterPrivateKey: requester.PrivateKey,
+			SenderPublicKey:     senders[i%len(senders)].PublicKey,
+		}
+	}
+	reqs[3].EncryptedData = encrypted[4].EncryptedData
+
+	results, err := Handler(BC25519, WithWorkers(3)).DecryptBatch(context.Background(), reqs)
+	assert.NoError(t, err)
+	assert.Len(t, results, len(reqs))
+	for i, result := range results {
+		if i == 3 {
+			assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(result.Err))
+			continue
+		}
+		assert.NoError(t, result.Err, i)
+		assert.Equal(t, fmt.Sprintf("entry %d", i), result.DecryptedData)
+	}
+}
@@ -0,0 +1,61 @@
+package decryption
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/internal/bench"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                           Benchmarks for Decrypt                           */
+/* -------------------------------------------------------------------------- */
+func BenchmarkDecrypt(b *testing.B) {
+	BC25519, err := utils.GetBC25519Curve()
+	if err != nil {
+		b.Fatal(err)
+	}
+	sender, err := keypairgen.Handler(BC25519).Generate()
+	if err != nil {
+		b.Fatal(err)
+	}
+	requester, err := keypairgen.Handler(BC25519).Generate()
+	if err != nil {
+		b.Fatal(err)
+	}
+	handler := Handler(BC25519)
+
+	for _, size := range bench.PayloadSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			encryptedData, err := encryption.Handler(BC25519).Encrypt(encryption.EncryptionRequest{
+				StringToEncrypt:    string(bytes.Repeat([]byte{'a'}, size)),
+				SenderNonce:        sender.Nonce,
+				RequesterNonce:     requester.Nonce,
+				SenderPrivateKey:   sender.PrivateKey,
+				RequesterPublicKey: requester.PublicKey,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			request := DecryptionRequest{
+				EncryptedData:       encryptedData,
+				SenderNonce:         sender.Nonce,
+				RequesterNonce:      requester.Nonce,
+				RequesterPrivateKey: requester.PrivateKey,
+				SenderPublicKey:     sender.PublicKey,
+			}
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := handler.Decrypt(request); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
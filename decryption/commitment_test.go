@@ -0,0 +1,208 @@
+package decryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for key commitment                          */
+/* -------------------------------------------------------------------------- */
+func TestKeyCommitment(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	generator := keypairgen.Handler(BC25519)
+	sender, err := generator.Generate()
+	assert.NoError(t, err)
+	requester, err := generator.Generate()
+	assert.NoError(t, err)
+
+	encrypted, err := encryption.Handler(BC25519, encryption.WithKeyCommitment()).Encrypt(encryption.EncryptionRequest{
+		StringToEncrypt:    "Hello, World!",
+		SenderNonce:        sender.Nonce,
+		RequesterNonce:     requester.Nonce,
+		SenderPrivateKey:   sender.PrivateKey,
+		RequesterPublicKey: requester.PublicKey,
+	})
+	assert.NoError(t, err)
+	req := DecryptionRequest{
+		EncryptedData:       encrypted,
+		SenderNonce:         sender.Nonce,
+		RequesterNonce:      requester.Nonce,
+		RequesterPrivateKey: requester.PrivateKey,
+		SenderPublicKey:     sender.PublicKey,
+	}
+	decrypted, err := Handler(BC25519, WithKeyCommitment()).Decrypt(req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!", decrypted)
+
+	// committed ciphertexts don't decrypt in the default mode, and vice versa
+	_, err = Handler(BC25519).Decrypt(req)
+	assert.Error(t, err)
+	b, err := base64.StdEncoding.DecodeString(encrypted)
+	assert.NoError(t, err)
+	req.EncryptedData = base64.StdEncoding.EncodeToString(b[utils.CommitmentSize:])
+	_, err = Handler(BC25519, WithKeyCommitment()).Decrypt(req)
+	assert.Error(t, err)
+}
+
+// TestKeyCommitmentRejectsGCMCollision builds a ciphertext that AES-GCM
+// accepts under the keys of two different requesters (an "invisible
+// salamander") and checks that only the committing mode tells them apart.
+func TestKeyCommitmentRejectsGCMCollision(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	generator := keypairgen.Handler(BC25519)
+	sender, err := generator.Generate()
+	assert.NoError(t, err)
+	alice, err := generator.Generate()
+	assert.NoError(t, err)
+	bob, err := generator.Generate()
+	assert.NoError(t, err)
+	requesterNonce := alice.Nonce
+
+	// Derive both AES keys the way the handlers do
+	senderNonce, _ := base64.StdEncoding.DecodeString(sender.Nonce)
+	nonce, _ := base64.StdEncoding.DecodeString(requesterNonce)
+	xorOfNonces, err := utils.XORBytes(senderNonce, nonce)
+	assert.NoError(t, err)
+	iv, salt := xorOfNonces[len(xorOfNonces)-12:], xorOfNonces[:20]
+	senderKey, err := utils.NewPrivateKeyECDH(BC25519, sender.PrivateKey)
+	assert.NoError(t, err)
+	deriveKey := func(publicKey string) ([]byte, *utils.Secret) {
+		point, err := utils.DecodeBase64ToPublicKey(publicKey, BC25519)
+		assert.NoError(t, err)
+		sharedSecret, err := senderKey.SharedSecret(point)
+		assert.NoError(t, err)
+		key, err := utils.Sha256HKDF(salt, sharedSecret, 32)
+		assert.NoError(t, err)
+		return key.Bytes(), sharedSecret
+	}
+	aliceKey, aliceSecret := deriveKey(alice.PublicKey)
+	bobKey, _ := deriveKey(bob.PublicKey)
+
+	collision := gcmCollision(t, aliceKey, bobKey, iv)
+	request := func(requester *keypairgen.KeyMaterial, data []byte) DecryptionRequest {
+		return DecryptionRequest{
+			EncryptedData:       base64.StdEncoding.EncodeToString(data),
+			SenderNonce:         sender.Nonce,
+			RequesterNonce:      requesterNonce,
+			RequesterPrivateKey: requester.PrivateKey,
+			SenderPublicKey:     sender.PublicKey,
+		}
+	}
+
+	// Without commitment both requesters accept it and see different data
+	forAlice, err := Handler(BC25519).Decrypt(request(alice, collision))
+	assert.NoError(t, err)
+	forBob, err := Handler(BC25519).Decrypt(request(bob, collision))
+	assert.NoError(t, err)
+	assert.NotEqual(t, forAlice, forBob)
+
+	// With commitment the tag names one key, and the other is rejected
+	commitment, err := utils.KeyCommitment(salt, aliceSecret)
+	assert.NoError(t, err)
+	committed := append(commitment, collision...)
+	handler := Handler(BC25519, WithKeyCommitment())
+	decrypted, err := handler.Decrypt(request(alice, committed))
+	assert.NoError(t, err)
+	assert.Equal(t, forAlice, decrypted)
+	_, err = handler.Decrypt(request(bob, committed))
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+}
+
+// gcmCollision returns a one-block ciphertext and tag that AES-GCM accepts
+// under both key1 and key2 with the given IV and no additional data. The tag
+// is E(J0) ⊕ C·H² ⊕ L·H, so C is chosen to make it equal for both keys:
+// C = (E1(J0) ⊕ E2(J0) ⊕ L·(H1 ⊕ H2)) / (H1² ⊕ H2²).
+func gcmCollision(t *testing.T, key1, key2, iv []byte) []byte {
+	h1, j1 := gcmSubkeys(t, key1, iv)
+	h2, j2 := gcmSubkeys(t, key2, iv)
+	var lengths gf128
+	lengths.lo = 128 // no additional data, one block of ciphertext
+
+	numerator := j1.xor(j2).xor(lengths.mul(h1.xor(h2)))
+	denominator := h1.mul(h1).xor(h2.mul(h2))
+	block := numerator.mul(denominator.inverse())
+	tag := j1.xor(block.mul(h1).mul(h1)).xor(lengths.mul(h1))
+
+	data := append(block.bytes(), tag.bytes()...)
+	for _, key := range [][]byte{key1, key2} {
+		aesBlock, err := aes.NewCipher(key)
+		assert.NoError(t, err)
+		aesGCM, err := cipher.NewGCM(aesBlock)
+		assert.NoError(t, err)
+		_, err = aesGCM.Open(nil, iv, data, nil)
+		assert.NoError(t, err, "collision should be valid under both keys")
+	}
+	return data
+}
+
+// gcmSubkeys returns the GHASH key H = E(0¹²⁸) and E(J0) for a 12-byte IV.
+func gcmSubkeys(t *testing.T, key, iv []byte) (gf128, gf128) {
+	block, err := aes.NewCipher(key)
+	assert.NoError(t, err)
+	h := make([]byte, 16)
+	block.Encrypt(h, h)
+	j0 := append(append([]byte(nil), iv...), 0, 0, 0, 1)
+	block.Encrypt(j0, j0)
+	return newGF128(h), newGF128(j0)
+}
+
+// gf128 is an element of GF(2¹²⁸) in GCM's bit order: the first bit of the
+// block is the coefficient of x⁰.
+type gf128 struct{ hi, lo uint64 }
+
+func newGF128(b []byte) gf128 {
+	return gf128{binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])}
+}
+
+func (a gf128) bytes() []byte {
+	b := binary.BigEndian.AppendUint64(nil, a.hi)
+	return binary.BigEndian.AppendUint64(b, a.lo)
+}
+
+func (a gf128) xor(b gf128) gf128 {
+	return gf128{a.hi ^ b.hi, a.lo ^ b.lo}
+}
+
+// mul is Algorithm 1 of NIST SP 800-38D.
+func (a gf128) mul(b gf128) gf128 {
+	var z gf128
+	v := b
+	for i := 0; i < 128; i++ {
+		word, bit := a.hi, 63-i
+		if i >= 64 {
+			word, bit = a.lo, 127-i
+		}
+		if word>>bit&1 == 1 {
+			z = z.xor(v)
+		}
+		carry := v.lo & 1
+		v.lo = v.lo>>1 | v.hi<<63
+		v.hi >>= 1
+		if carry == 1 {
+			v.hi ^= 0xe1 << 56
+		}
+	}
+	return z
+}
+
+// inverse returns a^(2¹²⁸-2) = a⁻¹.
+func (a gf128) inverse() gf128 {
+	result := gf128{hi: 1 << 63} // the multiplicative identity
+	square := a
+	for i := 1; i < 128; i++ {
+		square = square.mul(square)
+		result = result.mul(square)
+	}
+	return result
+}
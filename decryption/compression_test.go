@@ -0,0 +1,170 @@
+package decryption
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// compressionFixture holds a sender and requester key pair.
+type compressionFixture struct {
+	curve     *utils.Curve
+	sender    *keypairgen.KeyMaterial
+	requester *keypairgen.KeyMaterial
+}
+
+func newCompressionFixture(t *testing.T) *compressionFixture {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	generator := keypairgen.Handler(BC25519)
+	sender, err := generator.Generate()
+	assert.NoError(t, err)
+	requester, err := generator.Generate()
+	assert.NoError(t, err)
+	return &compressionFixture{curve: BC25519, sender: sender, requester: requester}
+}
+
+func (f *compressionFixture) encrypt(t *testing.T, data string, opts ...encryption.Option) string {
+	encrypted, err := encryption.Handler(f.curve, opts...).Encrypt(encryption.EncryptionRequest{
+		StringToEncrypt:    data,
+		SenderNonce:        f.sender.Nonce,
+		RequesterNonce:     f.requester.Nonce,
+		SenderPrivateKey:   f.sender.PrivateKey,
+		RequesterPublicKey: f.requester.PublicKey,
+	})
+	assert.NoError(t, err)
+	return encrypted
+}
+
+func (f *compressionFixture) request(encryptedData string) DecryptionRequest {
+	return DecryptionRequest{
+		EncryptedData:       encryptedData,
+		SenderNonce:         f.sender.Nonce,
+		RequesterNonce:      f.requester.Nonce,
+		RequesterPrivateKey: f.requester.PrivateKey,
+		SenderPublicKey:     f.sender.PublicKey,
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for compression                             */
+/* -------------------------------------------------------------------------- */
+func TestDecryptCompressed(t *testing.T) {
+	f := newCompressionFixture(t)
+	bundle := strings.Repeat(`{"resourceType":"Observation","status":"final"},`, 2000)
+	plain := f.encrypt(t, bundle)
+
+	for _, c := range []utils.Compression{utils.Deflate, utils.Gzip} {
+		encrypted := f.encrypt(t, bundle, encryption.WithCompression(c))
+		assert.Less(t, len(encrypted), len(plain)/5, c)
+
+		// decryption detects the header without any option
+		decrypted, err := Handler(f.curve).Decrypt(f.request(encrypted))
+		assert.NoError(t, err, c)
+		assert.Equal(t, bundle, decrypted)
+
+		// and works together with key commitment
+		encrypted = f.encrypt(t, bundle, encryption.WithCompression(c), encryption.WithKeyCommitment())
+		decrypted, err = Handler(f.curve, WithKeyCommitment()).Decrypt(f.request(encrypted))
+		assert.NoError(t, err, c)
+		assert.Equal(t, bundle, decrypted)
+	}
+}
+
+func TestDecryptCompressionLimit(t *testing.T) {
+	f := newCompressionFixture(t)
+	bundle := strings.Repeat("a", 1<<20)
+	encrypted := f.encrypt(t, bundle, encryption.WithCompression(utils.Gzip))
+
+	_, err := Handler(f.curve, WithMaxDecompressedSize(1<<16)).Decrypt(f.request(encrypted))
+	assert.ErrorIs(t, err, utils.ErrDecompressedTooLarge)
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+
+	decrypted, err := Handler(f.curve, WithMaxDecompressedSize(1<<20)).Decrypt(f.request(encrypted))
+	assert.NoError(t, err)
+	assert.Equal(t, bundle, decrypted)
+}
+
+func TestEncryptCompressionLimit(t *testing.T) {
+	f := newCompressionFixture(t)
+	bundle := strings.Repeat("a", 1<<16+1)
+
+	// encryption refuses plaintexts the decrypting side would reject
+	_, err := encryption.Handler(f.curve, encryption.WithCompression(utils.Gzip), encryption.WithMaxDecompressedSize(1<<16)).Encrypt(encryption.EncryptionRequest{
+		StringToEncrypt:    bundle,
+		SenderNonce:        f.sender.Nonce,
+		RequesterNonce:     f.requester.Nonce,
+		SenderPrivateKey:   f.sender.PrivateKey,
+		RequesterPublicKey: f.requester.PublicKey,
+	})
+	assert.ErrorIs(t, err, utils.ErrDecompressedTooLarge)
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+
+	// the limit only applies to compressed plaintexts
+	encrypted := f.encrypt(t, bundle, encryption.WithMaxDecompressedSize(1<<16))
+	decrypted, err := Handler(f.curve, WithMaxDecompressedSize(1<<16)).Decrypt(f.request(encrypted))
+	assert.NoError(t, err)
+	assert.Equal(t, bundle, decrypted)
+}
+
+func TestDecryptCompressionHeaderTampering(t *testing.T) {
+	f := newCompressionFixture(t)
+	encrypted := f.encrypt(t, "Hello, World!", encryption.WithCompression(utils.Gzip))
+	b, err := base64.StdEncoding.DecodeString(encrypted)
+	assert.NoError(t, err)
+
+	// the header is authenticated: changing or stripping it fails
+	tampered := append([]byte(nil), b...)
+	tampered[4] = byte(utils.Deflate)
+	_, err = Handler(f.curve).Decrypt(f.request(base64.StdEncoding.EncodeToString(tampered)))
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+	_, err = Handler(f.curve).Decrypt(f.request(base64.StdEncoding.EncodeToString(b[utils.EnvelopeHeaderSize:])))
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+
+	// a malformed header that also fails as a plain ciphertext is reported
+	// as an authentication failure, not as invalid input, whether the key
+	// is right or wrong
+	tampered = append([]byte(nil), b...)
+	tampered[3] = 0x09
+	_, err = Handler(f.curve).Decrypt(f.request(base64.StdEncoding.EncodeToString(tampered)))
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+	wrongKey := f.request(encrypted)
+	wrongKey.SenderNonce = f.requester.Nonce
+	_, err = Handler(f.curve).Decrypt(wrongKey)
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+	wrongKey.EncryptedData = base64.StdEncoding.EncodeToString(tampered)
+	_, err = Handler(f.curve).Decrypt(wrongKey)
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+}
+
+// TestDecryptMagicCollision checks that a plain Fidelius ciphertext that
+// happens to start with a valid envelope header still decrypts.
+func TestDecryptMagicCollision(t *testing.T) {
+	f := newCompressionFixture(t)
+	reference := f.encrypt(t, "\x00\x00\x00\x00\x00 rest of the message")
+	b, err := base64.StdEncoding.DecodeString(reference)
+	assert.NoError(t, err)
+
+	// GCM is a stream cipher, so the first bytes of the ciphertext are the
+	// keystream for an all-zero prefix; choose the prefix that encrypts to
+	// a gzip header.
+	header := utils.EnvelopeHeader{Compression: utils.Gzip}.Marshal()
+	prefix := make([]byte, len(header))
+	for i := range prefix {
+		prefix[i] = b[i] ^ header[i]
+	}
+	message := string(prefix) + " rest of the message"
+	encrypted := f.encrypt(t, message)
+	b, err = base64.StdEncoding.DecodeString(encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, header, b[:len(header)])
+
+	decrypted, err := Handler(f.curve).Decrypt(f.request(encrypted))
+	assert.NoError(t, err)
+	assert.Equal(t, message, decrypted)
+}
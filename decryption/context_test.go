@@ -0,0 +1,48 @@
+package decryption
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// cancellingKey cancels a context while computing the shared secret, as if
+// the deadline passed during the scalar multiplication.
+type cancellingKey struct {
+	utils.ECDHKey
+	cancel context.CancelFunc
+}
+
+func (k *cancellingKey) SharedSecret(peerPublicKey *utils.Point) (*utils.Secret, error) {
+	k.cancel()
+	return k.ECDHKey.SharedSecret(peerPublicKey)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for DecryptContext                          */
+/* -------------------------------------------------------------------------- */
+func TestDecryptContext(t *testing.T) {
+	f := newCompressionFixture(t)
+	req := f.request(f.encrypt(t, "Hello, World!"))
+	handler := Handler(f.curve)
+
+	decrypted, err := handler.DecryptContext(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!", decrypted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = handler.DecryptContext(ctx, req)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// cancellation during ECDH stops before HKDF
+	ctx, cancel = context.WithCancel(context.Background())
+	requesterKey, err := utils.NewPrivateKeyECDH(f.curve, f.requester.PrivateKey)
+	assert.NoError(t, err)
+	defer requesterKey.Destroy()
+	req.RequesterKey = &cancellingKey{ECDHKey: requesterKey, cancel: cancel}
+	_, err = handler.DecryptContext(ctx, req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -1,11 +1,13 @@
 package decryption
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
 
 	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/observe"
 	"github.com/zoop/fidelius-go/utils"
 )
 
@@ -14,48 +16,83 @@ import (
 /* -------------------------------------------------------------------------- */
 
 func (cc *decryptionHandler) Decrypt(req DecryptionRequest) (string, error) {
+	return cc.DecryptContext(context.Background(), req)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               DecryptContext                               */
+/* -------------------------------------------------------------------------- */
+// is Decrypt that stops with ctx.Err() once ctx is done. Cancellation is
+// checked between the expensive steps (ECDH, HKDF, opening, decompressing);
+// a step that has started runs to completion.
+func (cc *decryptionHandler) DecryptContext(ctx context.Context, req DecryptionRequest) (string, error) {
+	return cc.decrypt(ctx, req, cc.sharedSecret)
+}
+
+// decrypt is DecryptContext with the shared secret computation passed in,
+// so that DecryptBatch can compute each shared secret once.
+func (cc *decryptionHandler) decrypt(ctx context.Context, req DecryptionRequest, sharedSecretFn func(DecryptionRequest) (*utils.Secret, error)) (_ string, err error) {
+	ctx, span := cc.span(ctx, "")
+	defer func() { span.End(err) }()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	// Decode base64 nonces
 	senderNonce, err := base64.StdEncoding.DecodeString(req.SenderNonce)
 	if err != nil {
-		return "", err
+		return "", utils.WithKind(utils.ErrInvalidInput, err)
 	}
 	requesterNonce, err := base64.StdEncoding.DecodeString(req.RequesterNonce)
 	if err != nil {
-		return "", err
+		return "", utils.WithKind(utils.ErrInvalidInput, err)
 	}
 
 	// XOR nonces to generate IV and salt
 	xorOfNonces, err := utils.XORBytes(senderNonce, requesterNonce)
 	if err != nil {
-		return "", err
+		return "", utils.WithKind(utils.ErrInvalidInput, err)
+	}
+	if len(xorOfNonces) < 20 {
+		return "", utils.WithKind(utils.ErrInvalidInput, errors.New("nonces must be at least 20 bytes"))
 	}
 
 	iv := xorOfNonces[len(xorOfNonces)-12:] // Last 12 bytes for IV
 	salt := xorOfNonces[:20]                // First 20 bytes for salt
 
 	// Compute the shared secret
-	sharedSecret, err := utils.ComputeSharedSecret(req.RequesterPrivateKey, req.SenderPublicKey, cc.Curve)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	_, stage := cc.span(ctx, observe.StageECDH)
+	sharedSecret, err := sharedSecretFn(req)
+	stage.End(err)
 	if err != nil {
-		return "", errors.Wrap(err, "[Decrypt][utils.ComputeSharedSecret]")
+		return "", err
 	}
+	defer sharedSecret.Destroy()
 
 	// Derive the AES encryption key using HKDF
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	_, stage = cc.span(ctx, observe.StageHKDF)
 	aesEncryptionKey, err := utils.Sha256HKDF(salt, sharedSecret, 32)
+	stage.End(err)
 	if err != nil {
 		return "", err
 	}
+	defer aesEncryptionKey.Destroy()
 
-	// Decode base64 encrypted data and split data + tag
-	encryptedDataWithTag, err := base64.StdEncoding.DecodeString(req.EncryptedData)
+	// Decode base64 encrypted data
+	encryptedData, err := base64.StdEncoding.DecodeString(req.EncryptedData)
 	if err != nil {
-		return "", err
-	}
-	if len(encryptedDataWithTag) < 16 {
-		return "", errors.New("encrypted data too short")
+		return "", utils.WithKind(utils.ErrInvalidInput, err)
 	}
 
 	// Create AES cipher block
-	block, err := aes.NewCipher(aesEncryptionKey)
+	block, err := aes.NewCipher(aesEncryptionKey.Bytes())
 	if err != nil {
 		return "", errors.Wrap(err, "[Decrypt][aes.NewCipher]")
 	}
@@ -66,12 +103,91 @@ func (cc *decryptionHandler) Decrypt(req DecryptionRequest) (string, error) {
 		return "", err
 	}
 
-	// Decrypt the data
-	plaintext, err := aesGCM.Open(nil, iv, encryptedDataWithTag, nil)
+	// Decrypt behind the envelope header if there is one. A plain Fidelius
+	// ciphertext can start with the header magic by chance, so fall back to
+	// decrypting it whole if the header doesn't authenticate. If that fails
+	// too, report the authentication failure rather than a malformed header,
+	// so the error doesn't tell which check rejected the input.
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	_, stage = cc.span(ctx, observe.StageOpen)
+	header, body, hasHeader, headerErr := utils.ParseEnvelopeHeader(encryptedData)
+	if hasHeader && headerErr == nil {
+		plaintext, err := cc.open(aesGCM, iv, salt, sharedSecret, body, encryptedData[:utils.EnvelopeHeaderSize])
+		if err == nil {
+			stage.End(nil)
+			if err := ctx.Err(); err != nil {
+				return "", err
+			}
+			_, stage = cc.span(ctx, observe.StageDecompress)
+			plaintext, err = utils.Decompress(header.Compression, plaintext, cc.maxDecompressedSize())
+			stage.End(err)
+			if err != nil {
+				return "", errors.Wrap(err, "[Decrypt][utils.Decompress]")
+			}
+			span.SetSizes(len(plaintext), len(encryptedData))
+			return string(plaintext), nil
+		}
+	}
+	plaintext, err := cc.open(aesGCM, iv, salt, sharedSecret, encryptedData, nil)
+	stage.End(err)
 	if err != nil {
-		return "", errors.Wrap(err, "[Decrypt][aesGCM.Open]")
+		return "", err
 	}
 
 	// Return the decrypted string
+	span.SetSizes(len(plaintext), len(encryptedData))
 	return string(plaintext), nil
 }
+
+// sharedSecret computes the ECDH shared secret of the requester key and the
+// sender public key.
+func (cc *decryptionHandler) sharedSecret(req DecryptionRequest) (*utils.Secret, error) {
+	requesterKey := req.RequesterKey
+	if requesterKey == nil {
+		privateKey, destroy, err := utils.NewECDHKey(cc.Curve, req.RequesterPrivateKey, cc.X25519)
+		if err != nil {
+			return nil, errors.Wrap(err, "[Decrypt][utils.NewECDHKey]")
+		}
+		defer destroy()
+		requesterKey = privateKey
+	}
+	senderPublicKey, err := utils.DecodeBase64ToPublicKey(req.SenderPublicKey, cc.Curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Decrypt][utils.DecodeBase64ToPublicKey]")
+	}
+	sharedSecret, err := requesterKey.SharedSecret(senderPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Decrypt][requesterKey.SharedSecret]")
+	}
+	return sharedSecret, nil
+}
+
+// open checks the key commitment tag, if enabled, and decrypts data + tag
+// with header as additional data.
+func (cc *decryptionHandler) open(aesGCM cipher.AEAD, iv, salt []byte, sharedSecret *utils.Secret, data, header []byte) ([]byte, error) {
+	if cc.KeyCommitment {
+		// Check the key commitment tag before touching the ciphertext
+		if len(data) < utils.CommitmentSize {
+			return nil, utils.WithKind(utils.ErrInvalidInput, errors.New("encrypted data too short"))
+		}
+		ok, err := utils.VerifyKeyCommitment(salt, sharedSecret, data[:utils.CommitmentSize])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, utils.WithKind(utils.ErrDecryptionFailed, errors.New("key commitment mismatch"))
+		}
+		data = data[utils.CommitmentSize:]
+	}
+	if len(data) < 16 {
+		return nil, utils.WithKind(utils.ErrInvalidInput, errors.New("encrypted data too short"))
+	}
+
+	plaintext, err := aesGCM.Open(nil, iv, data, header)
+	if err != nil {
+		return nil, errors.Wrap(utils.WithKind(utils.ErrDecryptionFailed, err), "[Decrypt][aesGCM.Open]")
+	}
+	return plaintext, nil
+}
@@ -1,10 +1,17 @@
 package decryption
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/internal/secretwatch"
+	"github.com/zoop/fidelius-go/internal/testvectors"
 	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/keystore"
 	"github.com/zoop/fidelius-go/utils"
 
 	"github.com/stretchr/testify/assert"
@@ -68,3 +75,204 @@ func TestDecrypt(t *testing.T) {
 	assert.NotEmpty(t, resp)
 	assert.Equal(t, resp, dataToEncrypt)
 }
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for DecryptByKeyID                          */
+/* -------------------------------------------------------------------------- */
+
+func TestDecryptByKeyID(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyHandler := keypairgen.Handler(BC25519)
+
+	/* ------------------------- Requester Stores Keys -------------------------- */
+	requesterKeys, err := keyHandler.Generate()
+	assert.NoError(t, err)
+	store := keystore.NewMemoryStore()
+	entry := keystore.NewEntry("consent-artefact-1", requesterKeys, time.Hour)
+	entry.SingleUse = true
+	assert.NoError(t, store.Put(entry))
+
+	/* ------------------------------ Sender Encrypts --------------------------- */
+	senderKeys, err := keyHandler.Generate()
+	assert.NoError(t, err)
+	dataToEncrypt := "Hello, World!"
+	encrypted, err := encryption.Handler(BC25519).Encrypt(encryption.EncryptionRequest{
+		StringToEncrypt:    dataToEncrypt,
+		SenderNonce:        senderKeys.Nonce,
+		RequesterNonce:     requesterKeys.Nonce,
+		SenderPrivateKey:   senderKeys.PrivateKey,
+		RequesterPublicKey: requesterKeys.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	/* ------------------------- Requester Decrypts By ID ----------------------- */
+	decryptionHandler := Handler(BC25519, WithKeyStore(store))
+	req := KeyIDDecryptionRequest{
+		KeyID:           "consent-artefact-1",
+		SenderNonce:     senderKeys.Nonce,
+		SenderPublicKey: senderKeys.PublicKey,
+		EncryptedData:   encrypted,
+	}
+	// failed decryptions don't burn a single-use key
+	tampered := req
+	raw, err := base64.StdEncoding.DecodeString(encrypted)
+	assert.NoError(t, err)
+	raw[0] ^= 0x01
+	tampered.EncryptedData = base64.StdEncoding.EncodeToString(raw)
+	_, err = decryptionHandler.DecryptByKeyID(tampered)
+	assert.ErrorIs(t, err, utils.ErrDecryptionFailed)
+	wrongSender := req
+	wrongSender.SenderPublicKey = requesterKeys.PublicKey
+	_, err = decryptionHandler.DecryptByKeyID(wrongSender)
+	assert.Error(t, err)
+
+	resp, err := decryptionHandler.DecryptByKeyID(req)
+	assert.NoError(t, err)
+	assert.Equal(t, dataToEncrypt, resp)
+
+	// single-use keys are gone after the first decryption
+	_, err = decryptionHandler.DecryptByKeyID(req)
+	assert.ErrorIs(t, err, keystore.ErrNotFound)
+
+	_, err = Handler(BC25519).DecryptByKeyID(req)
+	assert.Error(t, err)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Tests for Decrypt zeroization                        */
+/* -------------------------------------------------------------------------- */
+
+func TestDecryptWipesSecrets(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	senderNonce := utils.GenerateRandomNonce(32)
+	requesterNonce := utils.GenerateRandomNonce(32)
+	encrypted, err := encryption.Handler(BC25519).Encrypt(encryption.EncryptionRequest{
+		StringToEncrypt:    "Hello, World!",
+		SenderNonce:        senderNonce,
+		RequesterNonce:     requesterNonce,
+		SenderPrivateKey:   keyMaterial.PrivateKey,
+		RequesterPublicKey: keyMaterial.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	var buffers [][]byte
+	restore := secretwatch.Set(func(buf []byte) {
+		buffers = append(buffers, buf)
+	})
+	defer restore()
+
+	_, err = Handler(BC25519).Decrypt(DecryptionRequest{
+		EncryptedData:       encrypted,
+		RequesterNonce:      requesterNonce,
+		SenderNonce:         senderNonce,
+		RequesterPrivateKey: keyMaterial.PrivateKey,
+		SenderPublicKey:     keyMaterial.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	// shared secret and AES key
+	assert.Len(t, buffers, 2)
+	for _, buf := range buffers {
+		assert.NotEmpty(t, buf)
+		assert.Equal(t, make([]byte, len(buf)), buf)
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                        Known-answer tests for Decrypt                      */
+/* -------------------------------------------------------------------------- */
+func TestDecryptVectors(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	vectors, err := testvectors.Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			request := DecryptionRequest{
+				EncryptedData:       v.EncryptedData,
+				SenderNonce:         v.SenderNonce,
+				RequesterNonce:      v.RequesterNonce,
+				RequesterPrivateKey: v.RequesterPrivateKey,
+				SenderPublicKey:     v.SenderPublicKey,
+			}
+			decryptedData, err := Handler(BC25519).Decrypt(request)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Plaintext, decryptedData)
+
+			decryptedData, err = Handler(BC25519, WithX25519()).Decrypt(request)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Plaintext, decryptedData)
+
+			// Any change to the ciphertext or tag must be rejected
+			tampered, err := base64.StdEncoding.DecodeString(v.EncryptedData)
+			assert.NoError(t, err)
+			tampered[len(tampered)-1] ^= 0x01
+			request.EncryptedData = base64.StdEncoding.EncodeToString(tampered)
+			_, err = Handler(BC25519).Decrypt(request)
+			assert.True(t, errors.Is(err, utils.ErrDecryptionFailed))
+		})
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                      Tests for Decrypt on other curves                     */
+/* -------------------------------------------------------------------------- */
+func TestDecryptCurves(t *testing.T) {
+	for _, constructor := range []func() (*utils.Curve, error){
+		utils.GetBC25519Curve, utils.GetP256Curve, utils.GetP384Curve, utils.GetSecp256k1Curve,
+	} {
+		curve, err := constructor()
+		assert.NoError(t, err)
+		t.Run(curve.Name, func(t *testing.T) {
+			sender, err := keypairgen.Handler(curve).Generate()
+			assert.NoError(t, err)
+			requester, err := keypairgen.Handler(curve).Generate()
+			assert.NoError(t, err)
+
+			encryptedData, err := encryption.Handler(curve).Encrypt(encryption.EncryptionRequest{
+				StringToEncrypt:    "Hello, World!",
+				SenderNonce:        sender.Nonce,
+				RequesterNonce:     requester.Nonce,
+				SenderPrivateKey:   sender.PrivateKey,
+				RequesterPublicKey: requester.X509PublicKey,
+			})
+			assert.NoError(t, err)
+
+			decryptedData, err := Handler(curve).Decrypt(DecryptionRequest{
+				EncryptedData:       encryptedData,
+				SenderNonce:         sender.Nonce,
+				RequesterNonce:      requester.Nonce,
+				RequesterPrivateKey: requester.PrivateKey,
+				SenderPublicKey:     sender.PublicKey,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, "Hello, World!", decryptedData)
+		})
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Tests for DecryptionRequest JSON                     */
+/* -------------------------------------------------------------------------- */
+
+func TestDecryptionRequestJSON(t *testing.T) {
+	data, err := json.Marshal(DecryptionRequest{
+		SenderNonce:         "a",
+		RequesterNonce:      "b",
+		RequesterPrivateKey: "c",
+		SenderPublicKey:     "d",
+		EncryptedData:       "e",
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"senderNonce":"a","requesterNonce":"b","requesterPrivateKey":"c","senderPublicKey":"d","encryptedData":"e"}`, string(data))
+
+	data, err = json.Marshal(KeyIDDecryptionRequest{KeyID: "k", SenderNonce: "a", SenderPublicKey: "d", EncryptedData: "e"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"keyId":"k","senderNonce":"a","senderPublicKey":"d","encryptedData":"e"}`, string(data))
+}
@@ -0,0 +1,51 @@
+package decryption
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/zoop/fidelius-go/internal/testvectors"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                Fuzz Decrypt                                */
+/* -------------------------------------------------------------------------- */
+func FuzzDecrypt(f *testing.F) {
+	BC25519, err := utils.GetBC25519Curve()
+	if err != nil {
+		f.Fatal(err)
+	}
+	vectors, err := testvectors.Load()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, v := range vectors {
+		f.Add(v.EncryptedData, v.SenderNonce, v.RequesterNonce, v.RequesterPrivateKey, v.SenderPublicKey)
+	}
+	// Short nonces and keys used to panic
+	v := vectors[0]
+	f.Add(v.EncryptedData, "", "", v.RequesterPrivateKey, v.SenderPublicKey)
+	f.Add(v.EncryptedData, "AAAA", "AAAA", v.RequesterPrivateKey, v.SenderPublicKey)
+	f.Add(v.EncryptedData, v.SenderNonce, v.RequesterNonce, v.RequesterPrivateKey, base64.StdEncoding.EncodeToString(make([]byte, 10)))
+	f.Add("", v.SenderNonce, v.RequesterNonce, "", "")
+
+	f.Fuzz(func(t *testing.T, encryptedData, senderNonce, requesterNonce, requesterPrivateKey, senderPublicKey string) {
+		decryptedData, err := Handler(BC25519).Decrypt(DecryptionRequest{
+			EncryptedData:       encryptedData,
+			SenderNonce:         senderNonce,
+			RequesterNonce:      requesterNonce,
+			RequesterPrivateKey: requesterPrivateKey,
+			SenderPublicKey:     senderPublicKey,
+		})
+		if err == nil {
+			return
+		}
+		if decryptedData != "" {
+			t.Fatalf("failed decryption returned data")
+		}
+		if utils.KindOf(err) == nil {
+			t.Fatalf("error has no kind: %v", err)
+		}
+	})
+}
@@ -1,17 +1,117 @@
 package decryption
 
-import "github.com/zoop/fidelius-go/utils"
+import (
+	"context"
+
+	"github.com/zoop/fidelius-go/keystore"
+	"github.com/zoop/fidelius-go/observe"
+	"github.com/zoop/fidelius-go/utils"
+)
 
 type decryptionHandler struct {
-	Curve *utils.Curve
+	Curve         *utils.Curve
+	KeyStore      keystore.KeyStore
+	X25519        bool
+	KeyCommitment bool
+	// MaxDecompressedSize bounds compressed plaintexts; zero means
+	// utils.DefaultMaxDecompressedSize.
+	MaxDecompressedSize int64
+	Workers             int
+	Hook                observe.Hook
 }
 
+// Option configures optional behaviour of the decryption handler.
+type Option func(*decryptionHandler)
+
 /* -------------------------------------------------------------------------- */
 /*                              DecryptionHandler                             */
 /* -------------------------------------------------------------------------- */
-func Handler(curve *utils.Curve) *decryptionHandler {
+func Handler(curve *utils.Curve, opts ...Option) *decryptionHandler {
 	controller := &decryptionHandler{
 		Curve: curve,
 	}
+	for _, opt := range opts {
+		opt(controller)
+	}
 	return controller
 }
+
+/* -------------------------------------------------------------------------- */
+/*                                WithKeyStore                                */
+/* -------------------------------------------------------------------------- */
+// lets the handler look up the requester key pair and nonce by key ID.
+func WithKeyStore(store keystore.KeyStore) Option {
+	return func(cc *decryptionHandler) {
+		cc.KeyStore = store
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 WithX25519                                 */
+/* -------------------------------------------------------------------------- */
+// computes shared secrets for RequesterPrivateKey with crypto/ecdh's X25519
+// (see utils.NewX25519ECDH). The output is unchanged. BC25519 only.
+func WithX25519() Option {
+	return func(cc *decryptionHandler) {
+		cc.X25519 = true
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              WithKeyCommitment                             */
+/* -------------------------------------------------------------------------- */
+// expects ciphertexts from encryption.WithKeyCommitment and checks the key
+// commitment tag before decrypting, so a ciphertext crafted to decrypt under
+// several keys is rejected.
+func WithKeyCommitment() Option {
+	return func(cc *decryptionHandler) {
+		cc.KeyCommitment = true
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           WithMaxDecompressedSize                          */
+/* -------------------------------------------------------------------------- */
+// sets the largest plaintext a compressed ciphertext may expand to (see
+// encryption.WithCompression). Larger ones fail with
+// utils.ErrDecompressedTooLarge.
+func WithMaxDecompressedSize(n int64) Option {
+	return func(cc *decryptionHandler) {
+		cc.MaxDecompressedSize = n
+	}
+}
+
+func (cc *decryptionHandler) maxDecompressedSize() int64 {
+	if cc.MaxDecompressedSize > 0 {
+		return cc.MaxDecompressedSize
+	}
+	return utils.DefaultMaxDecompressedSize
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 WithWorkers                                */
+/* -------------------------------------------------------------------------- */
+// sets the number of requests DecryptBatch processes concurrently; the
+// default is runtime.NumCPU().
+func WithWorkers(n int) Option {
+	return func(cc *decryptionHandler) {
+		cc.Workers = n
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  WithHook                                  */
+/* -------------------------------------------------------------------------- */
+// reports every Decrypt, and each of its stages, to hook (see package
+// observe).
+func WithHook(hook observe.Hook) Option {
+	return func(cc *decryptionHandler) {
+		cc.Hook = hook
+	}
+}
+
+// span starts an observe span for stage of Decrypt, or for the whole
+// operation if stage is empty.
+func (cc *decryptionHandler) span(ctx context.Context, stage observe.Stage) (context.Context, *observe.Span) {
+	return observe.Start(ctx, cc.Hook, observe.Event{Operation: observe.Decrypt, Stage: stage, Curve: cc.Curve.Name})
+}
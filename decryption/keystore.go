@@ -0,0 +1,47 @@
+package decryption
+
+import (
+	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/keystore"
+)
+
+var (
+	ErrKeyAlreadyUsed = errors.New("single-use key already used")
+)
+
+/* -------------------------------------------------------------------------- */
+/*                               DecryptByKeyID                               */
+/* -------------------------------------------------------------------------- */
+// decrypts using the requester key pair stored under req.KeyID. SingleUse
+// entries are deleted only once decryption succeeds, so tampered data or a
+// wrong sender key don't burn the key. If two decryptions race for the same
+// single-use key, only the first to delete it returns the plaintext; the
+// other fails with ErrKeyAlreadyUsed.
+func (cc *decryptionHandler) DecryptByKeyID(req KeyIDDecryptionRequest) (string, error) {
+	if cc.KeyStore == nil {
+		return "", errors.New("decryption handler has no key store")
+	}
+	entry, err := cc.KeyStore.Get(req.KeyID)
+	if err != nil {
+		return "", errors.Wrap(err, "[DecryptByKeyID][KeyStore.Get]")
+	}
+	decrypted, err := cc.Decrypt(DecryptionRequest{
+		SenderNonce:         req.SenderNonce,
+		RequesterNonce:      entry.Nonce,
+		RequesterPrivateKey: entry.PrivateKey,
+		SenderPublicKey:     req.SenderPublicKey,
+		EncryptedData:       req.EncryptedData,
+	})
+	if err != nil {
+		return "", err
+	}
+	if entry.SingleUse {
+		if err := cc.KeyStore.Delete(req.KeyID); err != nil {
+			if errors.Is(err, keystore.ErrNotFound) {
+				return "", ErrKeyAlreadyUsed
+			}
+			return "", errors.Wrap(err, "[DecryptByKeyID][KeyStore.Delete]")
+		}
+	}
+	return decrypted, nil
+}
@@ -1,9 +1,31 @@
 package decryption
 
+import "github.com/zoop/fidelius-go/utils"
+
 type DecryptionRequest struct {
-	SenderNonce         string
-	RequesterNonce      string
-	RequesterPrivateKey string
-	SenderPublicKey     string
-	EncryptedData       string
+	SenderNonce         string `json:"senderNonce"`
+	RequesterNonce      string `json:"requesterNonce"`
+	RequesterPrivateKey string `json:"requesterPrivateKey"`
+	SenderPublicKey     string `json:"senderPublicKey"`
+	EncryptedData       string `json:"encryptedData"`
+
+	// RequesterKey, when set, is used instead of RequesterPrivateKey so the
+	// private key never has to be loaded into this process.
+	RequesterKey utils.ECDHKey `json:"-"`
+}
+
+// KeyIDDecryptionRequest carries only what the sender returns; the requester
+// private key and nonce are fetched from the handler's key store.
+type KeyIDDecryptionRequest struct {
+	KeyID           string `json:"keyId"`
+	SenderNonce     string `json:"senderNonce"`
+	SenderPublicKey string `json:"senderPublicKey"`
+	EncryptedData   string `json:"encryptedData"`
+}
+
+// BatchResult is the outcome of one DecryptBatch request. Err is set when
+// the request failed.
+type BatchResult struct {
+	DecryptedData string
+	Err           error
 }
@@ -0,0 +1,65 @@
+package decryption
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/observe"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                             Tests for WithHook                             */
+/* -------------------------------------------------------------------------- */
+func TestDecryptHook(t *testing.T) {
+	f := newCompressionFixture(t)
+	plaintext := strings.Repeat("Hello, World! ", 10)
+	encrypted := f.encrypt(t, plaintext, encryption.WithCompression(utils.Deflate))
+
+	var logs bytes.Buffer
+	metrics := observe.NewMetrics()
+	tracer := observe.NewMemoryTracer()
+	hook := observe.Multi(
+		observe.Logger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		metrics,
+		observe.Tracing(tracer),
+	)
+	handler := Handler(f.curve, WithHook(hook))
+
+	decrypted, err := handler.Decrypt(f.request(encrypted))
+	assert.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	var names []string
+	for _, span := range tracer.Spans() {
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, []string{
+		"fidelius.decrypt.ecdh",
+		"fidelius.decrypt.hkdf",
+		"fidelius.decrypt.open",
+		"fidelius.decrypt.decompress",
+		"fidelius.decrypt",
+	}, names)
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricOperations, "decrypt", f.curve.Name, "OK"))
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricPayloadSize, "decrypt", "plaintext"))
+
+	// a ciphertext for another key fails in the open stage
+	other := newCompressionFixture(t)
+	_, err = handler.Decrypt(f.request(other.encrypt(t, plaintext)))
+	assert.ErrorIs(t, err, utils.ErrDecryptionFailed)
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricOperations, "decrypt", f.curve.Name, "DECRYPTION_FAILED"))
+	spans := tracer.Spans()
+	open := spans[len(spans)-2]
+	assert.Equal(t, "fidelius.decrypt.open", open.Name)
+	assert.ErrorIs(t, open.Err, utils.ErrDecryptionFailed)
+
+	for _, secret := range []string{f.requester.PrivateKey, f.sender.Nonce, f.requester.Nonce, encrypted, "Hello"} {
+		assert.NotContains(t, logs.String(), secret)
+	}
+	assert.Contains(t, logs.String(), `"error_code":"DECRYPTION_FAILED"`)
+}
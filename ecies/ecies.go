@@ -0,0 +1,157 @@
+package ecies
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// kdfInfo separates ECIES keys from keys derived from the same shared secret
+// by the ABDM flow, which uses HKDF without info.
+const kdfInfo = "fidelius-go/ecies/v1"
+
+/* -------------------------------------------------------------------------- */
+/*                                   Encrypt                                  */
+/* -------------------------------------------------------------------------- */
+// encrypts plaintext to the base64 encoded recipient public key (uncompressed
+// or X.509) with a fresh ephemeral key pair and nonce, and returns the
+// envelope base64 encoded.
+func (h *eciesHandler) Encrypt(recipientPublicKey string, plaintext []byte) (string, error) {
+	recipient, err := utils.DecodeBase64ToPublicKey(recipientPublicKey, h.Curve)
+	if err != nil {
+		return "", errors.Wrap(err, "[Encrypt][utils.DecodeBase64ToPublicKey]")
+	}
+
+	// Generate the ephemeral key pair and nonce
+	ephemeralKey, ephemeralPublicKey, nonce, err := h.ephemeral()
+	if err != nil {
+		return "", errors.Wrap(err, "[Encrypt][ephemeral]")
+	}
+	defer ephemeralKey.Destroy()
+
+	envelope := &Envelope{EphemeralPublicKey: ephemeralPublicKey, Nonce: nonce}
+	aesGCM, err := h.newGCM(ephemeralKey, recipient, ephemeralPublicKey, nonce, kdfInfo, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "[Encrypt][newGCM]")
+	}
+	envelope.Ciphertext = aesGCM.Seal(nil, iv(nonce), plaintext, envelope.header())
+	return utils.EncodeBase64(envelope.Marshal()), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Decrypt                                  */
+/* -------------------------------------------------------------------------- */
+// decrypts a base64 encoded envelope with the recipient's base64 encoded
+// private key.
+func (h *eciesHandler) Decrypt(recipientPrivateKey string, envelope string) ([]byte, error) {
+	recipientKey, err := utils.NewPrivateKeyECDH(h.Curve, recipientPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Decrypt][utils.NewPrivateKeyECDH]")
+	}
+	defer recipientKey.Destroy()
+	return h.DecryptWithKey(recipientKey, envelope)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               DecryptWithKey                               */
+/* -------------------------------------------------------------------------- */
+// is Decrypt for a recipient key held outside this process, such as a
+// remotekey or a key store entry.
+func (h *eciesHandler) DecryptWithKey(recipientKey utils.ECDHKey, envelope string) ([]byte, error) {
+	b, err := utils.DecodeBase64(envelope)
+	if err != nil {
+		return nil, utils.WithKind(utils.ErrInvalidInput, err)
+	}
+	parsed, err := ParseEnvelope(b, h.Curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "[DecryptWithKey][ParseEnvelope]")
+	}
+	aesGCM, err := h.newGCM(recipientKey, nil, parsed.EphemeralPublicKey, parsed.Nonce, kdfInfo, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "[DecryptWithKey][newGCM]")
+	}
+	plaintext, err := aesGCM.Open(nil, iv(parsed.Nonce), parsed.Ciphertext, parsed.header())
+	if err != nil {
+		return nil, errors.Wrap(utils.WithKind(utils.ErrDecryptionFailed, err), "[DecryptWithKey][aesGCM.Open]")
+	}
+	return plaintext, nil
+}
+
+// wipeableKey is a private key the caller can wipe.
+type wipeableKey interface {
+	utils.ECDHKey
+	Destroy()
+}
+
+// ephemeral generates an envelope's ephemeral key pair and nonce. The private
+// scalar is never encoded, so Destroy on the returned key wipes it.
+func (h *eciesHandler) ephemeral() (wipeableKey, *utils.Point, []byte, error) {
+	key, err := utils.GenerateECDHKey(h.Curve)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "[ephemeral][utils.GenerateECDHKey]")
+	}
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		key.Destroy()
+		return nil, nil, nil, errors.Wrap(err, "[ephemeral][key.PublicKey]")
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		key.Destroy()
+		return nil, nil, nil, errors.Wrap(err, "[ephemeral][rand.Read]")
+	}
+	return key, publicKey, nonce, nil
+}
+
+// newGCM derives an AES-GCM key from ECDH with the envelope's ephemeral key.
+// key is the ephemeral key with the recipient's public key as peer when
+// encrypting, and the recipient's key with a nil peer, meaning the ephemeral
+// public key, when decrypting. The HKDF info is info followed by both public
+// keys and keyID.
+func (h *eciesHandler) newGCM(key utils.ECDHKey, peer, ephemeralPublicKey *utils.Point, nonce []byte, info string, keyID []byte) (cipher.AEAD, error) {
+	ownPublicKey, err := key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	recipient := peer
+	if peer == nil {
+		peer, recipient = ephemeralPublicKey, ownPublicKey
+	}
+	sharedSecret, err := key.SharedSecret(peer)
+	if err != nil {
+		return nil, err
+	}
+	defer sharedSecret.Destroy()
+
+	kdfInfo := []byte(info)
+	kdfInfo = append(kdfInfo, utils.EncodePublicKey(h.Curve, ephemeralPublicKey.X, ephemeralPublicKey.Y)...)
+	kdfInfo = append(kdfInfo, utils.EncodePublicKey(h.Curve, recipient.X, recipient.Y)...)
+	kdfInfo = append(kdfInfo, keyID...)
+	keys, err := utils.DeriveKeys(sha256.New, sharedSecret, nonce, kdfInfo, 32, 1)
+	if err != nil {
+		return nil, err
+	}
+	aesKey := keys[0]
+	defer aesKey.Destroy()
+	return newAESGCM(aesKey)
+}
+
+// newAESGCM returns AES-256-GCM with key.
+func newAESGCM(key *utils.Secret) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// iv returns the GCM nonce: the last 12 bytes of the envelope nonce, as in
+// the ABDM flow. Every envelope has its own key, so the IV is never reused
+// under a key.
+func iv(nonce []byte) []byte {
+	return nonce[len(nonce)-12:]
+}
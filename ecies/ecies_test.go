@@ -0,0 +1,150 @@
+package ecies
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                        Tests for Encrypt and Decrypt                       */
+/* -------------------------------------------------------------------------- */
+func TestEncryptDecrypt(t *testing.T) {
+	for _, get := range []func() (*utils.Curve, error){
+		utils.GetBC25519Curve, utils.GetP256Curve, utils.GetP384Curve, utils.GetSecp256k1Curve,
+	} {
+		curve, err := get()
+		assert.NoError(t, err)
+		handler := Handler(curve)
+		recipient, err := keypairgen.Handler(curve).Generate()
+		assert.NoError(t, err)
+		plaintext := []byte(`{"resourceType":"Bundle"}`)
+
+		envelope, err := handler.Encrypt(recipient.PublicKey, plaintext)
+		assert.NoError(t, err, curve.Name)
+		decrypted, err := handler.Decrypt(recipient.PrivateKey, envelope)
+		assert.NoError(t, err, curve.Name)
+		assert.Equal(t, plaintext, decrypted)
+
+		// X.509 recipient keys work too, and every envelope is fresh
+		again, err := handler.Encrypt(recipient.X509PublicKey, plaintext)
+		assert.NoError(t, err)
+		assert.NotEqual(t, envelope, again)
+		decrypted, err = handler.Decrypt(recipient.PrivateKey, again)
+		assert.NoError(t, err)
+		assert.Equal(t, plaintext, decrypted)
+	}
+}
+
+func TestEncryptEmpty(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	recipient, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+
+	envelope, err := handler.Encrypt(recipient.PublicKey, nil)
+	assert.NoError(t, err)
+	b, err := utils.DecodeBase64(envelope)
+	assert.NoError(t, err)
+	assert.Len(t, b, 1+65+nonceSize+tagSize)
+	decrypted, err := handler.Decrypt(recipient.PrivateKey, envelope)
+	assert.NoError(t, err)
+	assert.Empty(t, decrypted)
+}
+
+func TestDecryptWithKey(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	recipient, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	envelope, err := handler.Encrypt(recipient.PublicKey, []byte("hello"))
+	assert.NoError(t, err)
+
+	key, err := utils.NewX25519ECDH(BC25519, recipient.PrivateKey)
+	assert.NoError(t, err)
+	defer key.Destroy()
+	decrypted, err := handler.DecryptWithKey(key, envelope)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), decrypted)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for invalid input                           */
+/* -------------------------------------------------------------------------- */
+// The ephemeral key is generated straight into memory the caller can wipe;
+// after Destroy it can no longer derive anything.
+func TestEphemeral(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	key, publicKey, nonce, err := Handler(BC25519).ephemeral()
+	assert.NoError(t, err)
+	assert.Len(t, nonce, nonceSize)
+	assert.True(t, BC25519.IsPointOnCurve(publicKey.X, publicKey.Y))
+
+	peer, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	peerPublicKey, err := utils.DecodeBase64ToPublicKey(peer.PublicKey, BC25519)
+	assert.NoError(t, err)
+	_, err = key.SharedSecret(peerPublicKey)
+	assert.NoError(t, err)
+	key.Destroy()
+	_, err = key.SharedSecret(peerPublicKey)
+	assert.Error(t, err)
+}
+
+func TestDecryptRejects(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	generator := keypairgen.Handler(BC25519)
+	recipient, err := generator.Generate()
+	assert.NoError(t, err)
+	other, err := generator.Generate()
+	assert.NoError(t, err)
+	envelope, err := handler.Encrypt(recipient.PublicKey, []byte("hello"))
+	assert.NoError(t, err)
+	b, err := utils.DecodeBase64(envelope)
+	assert.NoError(t, err)
+
+	_, err = handler.Decrypt(other.PrivateKey, envelope)
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+
+	// flipping a byte of the nonce or the ciphertext breaks authentication
+	for _, i := range []int{1 + 65, len(b) - 1} {
+		tampered := append([]byte(nil), b...)
+		tampered[i] ^= 0x01
+		_, err = handler.Decrypt(recipient.PrivateKey, utils.EncodeBase64(tampered))
+		assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err), i)
+	}
+
+	// an ephemeral key off the curve is rejected before ECDH
+	tampered := append([]byte(nil), b...)
+	tampered[2] ^= 0x01
+	_, err = handler.Decrypt(recipient.PrivateKey, utils.EncodeBase64(tampered))
+	assert.Equal(t, utils.ErrInvalidKey, utils.KindOf(err))
+
+	tampered = append([]byte(nil), b...)
+	tampered[0] = 0x02
+	_, err = handler.Decrypt(recipient.PrivateKey, utils.EncodeBase64(tampered))
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+
+	_, err = handler.Decrypt(recipient.PrivateKey, utils.EncodeBase64(b[:len(b)-tagSize-1]))
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+	_, err = handler.Decrypt(recipient.PrivateKey, "not base64")
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+
+	// an envelope made on another curve doesn't parse
+	P256, err := utils.GetP256Curve()
+	assert.NoError(t, err)
+	p256Recipient, err := keypairgen.Handler(P256).Generate()
+	assert.NoError(t, err)
+	p256Envelope, err := Handler(P256).Encrypt(p256Recipient.PublicKey, []byte("hello"))
+	assert.NoError(t, err)
+	_, err = handler.Decrypt(recipient.PrivateKey, p256Envelope)
+	assert.NotNil(t, utils.KindOf(err))
+}
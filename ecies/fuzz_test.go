@@ -0,0 +1,115 @@
+package ecies
+
+import (
+	"testing"
+
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                               Fuzz Envelope                                */
+/* -------------------------------------------------------------------------- */
+func FuzzEnvelope(f *testing.F) {
+	BC25519, err := utils.GetBC25519Curve()
+	if err != nil {
+		f.Fatal(err)
+	}
+	handler := Handler(BC25519)
+	recipient, err := keypairgen.Handler(BC25519).Generate()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, plaintext := range []string{"", "hello", `{"resourceType":"Bundle"}`} {
+		envelope, err := handler.Encrypt(recipient.PublicKey, []byte(plaintext))
+		if err != nil {
+			f.Fatal(err)
+		}
+		b, err := utils.DecodeBase64(envelope)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{envelopeVersion})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		envelope, err := ParseEnvelope(b, BC25519)
+		if err != nil {
+			if utils.KindOf(err) == nil {
+				t.Fatalf("error has no kind: %v", err)
+			}
+			return
+		}
+		if string(envelope.Marshal()) != string(b) {
+			t.Fatalf("envelope doesn't round trip")
+		}
+		plaintext, err := handler.Decrypt(recipient.PrivateKey, utils.EncodeBase64(b))
+		if err == nil {
+			return
+		}
+		if plaintext != nil {
+			t.Fatalf("failed decryption returned data")
+		}
+		if utils.KindOf(err) == nil {
+			t.Fatalf("error has no kind: %v", err)
+		}
+	})
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Fuzz MultiEnvelope                              */
+/* -------------------------------------------------------------------------- */
+func FuzzMultiEnvelope(f *testing.F) {
+	BC25519, err := utils.GetBC25519Curve()
+	if err != nil {
+		f.Fatal(err)
+	}
+	handler := Handler(BC25519)
+	generator := keypairgen.Handler(BC25519)
+	alice, err := generator.Generate()
+	if err != nil {
+		f.Fatal(err)
+	}
+	bob, err := generator.Generate()
+	if err != nil {
+		f.Fatal(err)
+	}
+	envelope, err := handler.EncryptMulti([]Recipient{
+		{KeyID: "alice", PublicKey: alice.PublicKey},
+		{KeyID: "bob", PublicKey: bob.PublicKey},
+	}, []byte("hello"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	b, err := utils.DecodeBase64(envelope)
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(b)
+	f.Add([]byte{multiEnvelopeVersion})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		envelope, err := ParseMultiEnvelope(b, BC25519)
+		if err != nil {
+			if utils.KindOf(err) == nil {
+				t.Fatalf("error has no kind: %v", err)
+			}
+			return
+		}
+		if string(envelope.Marshal()) != string(b) {
+			t.Fatalf("envelope doesn't round trip")
+		}
+		plaintext, err := handler.DecryptMulti("alice", alice.PrivateKey, utils.EncodeBase64(b))
+		if err == nil {
+			return
+		}
+		if plaintext != nil {
+			t.Fatalf("failed decryption returned data")
+		}
+		if utils.KindOf(err) == nil {
+			t.Fatalf("error has no kind: %v", err)
+		}
+	})
+}
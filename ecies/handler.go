@@ -0,0 +1,30 @@
+// Package ecies implements one-pass hybrid encryption to a recipient's public
+// key. Unlike the ABDM flow, which needs key material and a nonce from both
+// sides, the sender generates an ephemeral key pair and nonce per message and
+// sends them along in the envelope, so decryption needs only the recipient's
+// private key.
+package ecies
+
+import (
+	"errors"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+var (
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+)
+
+type eciesHandler struct {
+	Curve *utils.Curve
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                ECIESHandler                                */
+/* -------------------------------------------------------------------------- */
+func Handler(curve *utils.Curve) *eciesHandler {
+	handler := &eciesHandler{
+		Curve: curve,
+	}
+	return handler
+}
@@ -0,0 +1,208 @@
+package ecies
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+const (
+	// envelopeVersion is the first byte of every envelope.
+	envelopeVersion = 0x01
+	// nonceSize is the length of the envelope nonce.
+	nonceSize = 32
+	// tagSize is the length of the AES-GCM tag at the end of the ciphertext.
+	tagSize = 16
+)
+
+// Envelope is the output of Encrypt. It is encoded as
+//
+//	version (1) || ephemeral public key (uncompressed) || nonce (32) || ciphertext || tag (16)
+//
+// and everything before the ciphertext is authenticated as AES-GCM
+// additional data.
+type Envelope struct {
+	EphemeralPublicKey *utils.Point
+	Nonce              []byte
+	Ciphertext         []byte
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Marshal                                  */
+/* -------------------------------------------------------------------------- */
+// returns the binary encoding of the envelope.
+func (e *Envelope) Marshal() []byte {
+	return append(e.header(), e.Ciphertext...)
+}
+
+// header returns the authenticated part of the envelope.
+func (e *Envelope) header() []byte {
+	publicKey := utils.EncodePublicKey(e.EphemeralPublicKey.Curve, e.EphemeralPublicKey.X, e.EphemeralPublicKey.Y)
+	header := make([]byte, 0, 1+len(publicKey)+len(e.Nonce))
+	header = append(header, envelopeVersion)
+	header = append(header, publicKey...)
+	return append(header, e.Nonce...)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                ParseEnvelope                               */
+/* -------------------------------------------------------------------------- */
+// parses a binary envelope made on curve and checks that the ephemeral
+// public key is on the curve.
+func ParseEnvelope(b []byte, curve *utils.Curve) (*Envelope, error) {
+	if curve == nil {
+		return nil, errors.New("curve cannot be nil")
+	}
+	publicKeySize := 1 + 2*curve.CoordinateSize()
+	if len(b) < 1+publicKeySize+nonceSize+tagSize {
+		return nil, utils.WithKind(utils.ErrInvalidInput, fmt.Errorf("%w: too short", ErrInvalidEnvelope))
+	}
+	if b[0] != envelopeVersion {
+		return nil, utils.WithKind(utils.ErrInvalidInput, fmt.Errorf("%w: unsupported version %d", ErrInvalidEnvelope, b[0]))
+	}
+	b = b[1:]
+	publicKey, err := utils.DecodePublicKey(b[:publicKeySize], curve)
+	if err != nil {
+		return nil, err
+	}
+	b = b[publicKeySize:]
+	return &Envelope{
+		EphemeralPublicKey: publicKey,
+		Nonce:              append([]byte(nil), b[:nonceSize]...),
+		Ciphertext:         append([]byte(nil), b[nonceSize:]...),
+	}, nil
+}
+
+const (
+	// multiEnvelopeVersion is the first byte of every multi-recipient
+	// envelope.
+	multiEnvelopeVersion = 0x02
+	// wrappedKeySize is the length of a content key sealed for a recipient.
+	wrappedKeySize = contentKeySize + tagSize
+	// contentKeySize is the length of the AES-256 content key.
+	contentKeySize = 32
+)
+
+// Recipient is a public key to encrypt to and the ID it is known by. An
+// empty KeyID defaults to the key's Fingerprint.
+type Recipient struct {
+	KeyID     string `json:"keyId"`
+	PublicKey string `json:"publicKey"`
+}
+
+// WrappedKey is the content key sealed for one recipient.
+type WrappedKey struct {
+	KeyID      string
+	WrappedKey []byte
+}
+
+// MultiEnvelope is the output of EncryptMulti. It is encoded as
+//
+//	version (1) || ephemeral public key (uncompressed) || nonce (32) ||
+//	recipient count (2) || { key ID length (1) || key ID || wrapped key (48) }... ||
+//	ciphertext || tag (16)
+//
+// and everything before the ciphertext, including the recipient list, is
+// authenticated as AES-GCM additional data. That only binds it against
+// outsiders: any recipient knows the content key and can re-seal the
+// envelope, so it says nothing about who produced it.
+type MultiEnvelope struct {
+	EphemeralPublicKey *utils.Point
+	Nonce              []byte
+	Recipients         []WrappedKey
+	Ciphertext         []byte
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Marshal                                  */
+/* -------------------------------------------------------------------------- */
+// returns the binary encoding of the envelope.
+func (e *MultiEnvelope) Marshal() []byte {
+	return append(e.header(), e.Ciphertext...)
+}
+
+// header returns the authenticated part of the envelope.
+func (e *MultiEnvelope) header() []byte {
+	header := []byte{multiEnvelopeVersion}
+	header = append(header, utils.EncodePublicKey(e.EphemeralPublicKey.Curve, e.EphemeralPublicKey.X, e.EphemeralPublicKey.Y)...)
+	header = append(header, e.Nonce...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(e.Recipients)))
+	for _, recipient := range e.Recipients {
+		header = append(header, byte(len(recipient.KeyID)))
+		header = append(header, recipient.KeyID...)
+		header = append(header, recipient.WrappedKey...)
+	}
+	return header
+}
+
+// recipient returns the wrapped key for keyID.
+func (e *MultiEnvelope) recipient(keyID string) (*WrappedKey, bool) {
+	for i := range e.Recipients {
+		if e.Recipients[i].KeyID == keyID {
+			return &e.Recipients[i], true
+		}
+	}
+	return nil, false
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             ParseMultiEnvelope                             */
+/* -------------------------------------------------------------------------- */
+// parses a binary multi-recipient envelope made on curve and checks that the
+// ephemeral public key is on the curve.
+func ParseMultiEnvelope(b []byte, curve *utils.Curve) (*MultiEnvelope, error) {
+	if curve == nil {
+		return nil, errors.New("curve cannot be nil")
+	}
+	invalid := func(reason string) error {
+		return utils.WithKind(utils.ErrInvalidInput, fmt.Errorf("%w: %s", ErrInvalidEnvelope, reason))
+	}
+	publicKeySize := 1 + 2*curve.CoordinateSize()
+	if len(b) < 1+publicKeySize+nonceSize+2 {
+		return nil, invalid("too short")
+	}
+	if b[0] != multiEnvelopeVersion {
+		return nil, invalid(fmt.Sprintf("unsupported version %d", b[0]))
+	}
+	b = b[1:]
+	publicKey, err := utils.DecodePublicKey(b[:publicKeySize], curve)
+	if err != nil {
+		return nil, err
+	}
+	b = b[publicKeySize:]
+	envelope := &MultiEnvelope{
+		EphemeralPublicKey: publicKey,
+		Nonce:              append([]byte(nil), b[:nonceSize]...),
+	}
+	b = b[nonceSize:]
+
+	count := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if count == 0 {
+		return nil, invalid("no recipients")
+	}
+	seen := make(map[string]bool, count)
+	for i := 0; i < count; i++ {
+		if len(b) < 1 || len(b) < 1+int(b[0])+wrappedKeySize {
+			return nil, invalid("truncated recipient list")
+		}
+		keyID := string(b[1 : 1+int(b[0])])
+		b = b[1+int(b[0]):]
+		if seen[keyID] {
+			return nil, invalid("duplicate key ID")
+		}
+		seen[keyID] = true
+		envelope.Recipients = append(envelope.Recipients, WrappedKey{
+			KeyID:      keyID,
+			WrappedKey: append([]byte(nil), b[:wrappedKeySize]...),
+		})
+		b = b[wrappedKeySize:]
+	}
+	if len(b) < tagSize {
+		return nil, invalid("too short")
+	}
+	envelope.Ciphertext = append([]byte(nil), b...)
+	return envelope, nil
+}
@@ -0,0 +1,186 @@
+package ecies
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// wrapInfo separates recipient key-wrapping keys from single-recipient
+// envelope keys.
+const wrapInfo = "fidelius-go/ecies/multi/v1"
+
+// maxKeyIDLength is the longest key ID an envelope can carry.
+const maxKeyIDLength = 255
+
+/* -------------------------------------------------------------------------- */
+/*                                 Fingerprint                                */
+/* -------------------------------------------------------------------------- */
+// returns the default key ID of a base64 encoded public key: the hex encoded
+// first 16 bytes of the SHA-256 of its uncompressed form. The uncompressed
+// and X.509 forms of a key have the same fingerprint.
+func (h *eciesHandler) Fingerprint(publicKey string) (string, error) {
+	point, err := utils.DecodeBase64ToPublicKey(publicKey, h.Curve)
+	if err != nil {
+		return "", errors.Wrap(err, "[Fingerprint][utils.DecodeBase64ToPublicKey]")
+	}
+	return h.fingerprint(point), nil
+}
+
+func (h *eciesHandler) fingerprint(point *utils.Point) string {
+	sum := sha256.Sum256(utils.EncodePublicKey(h.Curve, point.X, point.Y))
+	return hex.EncodeToString(sum[:16])
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                EncryptMulti                                */
+/* -------------------------------------------------------------------------- */
+// encrypts plaintext once under a random content key and wraps that key for
+// each recipient with ECDH and HKDF, so any one recipient can decrypt. It
+// returns the multi-recipient envelope base64 encoded. The envelope is
+// confidential but not sender-authenticated between recipients; sign it with
+// the signing package when that matters.
+func (h *eciesHandler) EncryptMulti(recipients []Recipient, plaintext []byte) (string, error) {
+	if len(recipients) == 0 || len(recipients) > 0xffff {
+		return "", utils.WithKind(utils.ErrInvalidInput, errors.New("need between 1 and 65535 recipients"))
+	}
+
+	// Generate the ephemeral key pair, nonce and content key
+	ephemeralKey, ephemeralPublicKey, nonce, err := h.ephemeral()
+	if err != nil {
+		return "", errors.Wrap(err, "[EncryptMulti][ephemeral]")
+	}
+	defer ephemeralKey.Destroy()
+	contentKey := make([]byte, contentKeySize)
+	if _, err := rand.Read(contentKey); err != nil {
+		return "", errors.Wrap(err, "[EncryptMulti][rand.Read]")
+	}
+	contentSecret := utils.NewSecret(contentKey)
+	defer contentSecret.Destroy()
+
+	// Wrap the content key for every recipient
+	envelope := &MultiEnvelope{EphemeralPublicKey: ephemeralPublicKey, Nonce: nonce}
+	seen := make(map[string]bool, len(recipients))
+	for _, recipient := range recipients {
+		point, err := utils.DecodeBase64ToPublicKey(recipient.PublicKey, h.Curve)
+		if err != nil {
+			return "", errors.Wrap(err, "[EncryptMulti][utils.DecodeBase64ToPublicKey]")
+		}
+		keyID := recipient.KeyID
+		if keyID == "" {
+			keyID = h.fingerprint(point)
+		}
+		if len(keyID) > maxKeyIDLength {
+			return "", utils.WithKind(utils.ErrInvalidInput, errors.New("key ID is longer than 255 bytes"))
+		}
+		if seen[keyID] {
+			return "", utils.WithKind(utils.ErrInvalidInput, errors.New("duplicate key ID "+keyID))
+		}
+		seen[keyID] = true
+
+		wrapGCM, err := h.newGCM(ephemeralKey, point, ephemeralPublicKey, nonce, wrapInfo, []byte(keyID))
+		if err != nil {
+			return "", errors.Wrap(err, "[EncryptMulti][newGCM]")
+		}
+		envelope.Recipients = append(envelope.Recipients, WrappedKey{
+			KeyID:      keyID,
+			WrappedKey: wrapGCM.Seal(nil, iv(nonce), contentSecret.Bytes(), []byte(keyID)),
+		})
+	}
+
+	// Encrypt the payload once
+	contentGCM, err := newAESGCM(contentSecret)
+	if err != nil {
+		return "", errors.Wrap(err, "[EncryptMulti][newAESGCM]")
+	}
+	envelope.Ciphertext = contentGCM.Seal(nil, iv(nonce), plaintext, envelope.header())
+	return utils.EncodeBase64(envelope.Marshal()), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                DecryptMulti                                */
+/* -------------------------------------------------------------------------- */
+// decrypts a base64 encoded multi-recipient envelope as the recipient keyID
+// with its base64 encoded private key. An empty keyID means the key's
+// Fingerprint.
+func (h *eciesHandler) DecryptMulti(keyID, recipientPrivateKey, envelope string) ([]byte, error) {
+	recipientKey, err := utils.NewPrivateKeyECDH(h.Curve, recipientPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "[DecryptMulti][utils.NewPrivateKeyECDH]")
+	}
+	defer recipientKey.Destroy()
+	return h.DecryptMultiWithKey(keyID, recipientKey, envelope)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             DecryptMultiWithKey                            */
+/* -------------------------------------------------------------------------- */
+// is DecryptMulti for a recipient key held outside this process.
+func (h *eciesHandler) DecryptMultiWithKey(keyID string, recipientKey utils.ECDHKey, envelope string) ([]byte, error) {
+	b, err := utils.DecodeBase64(envelope)
+	if err != nil {
+		return nil, utils.WithKind(utils.ErrInvalidInput, err)
+	}
+	parsed, err := ParseMultiEnvelope(b, h.Curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "[DecryptMultiWithKey][ParseMultiEnvelope]")
+	}
+	if keyID == "" {
+		publicKey, err := recipientKey.PublicKey()
+		if err != nil {
+			return nil, errors.Wrap(err, "[DecryptMultiWithKey][recipientKey.PublicKey]")
+		}
+		keyID = h.fingerprint(publicKey)
+	}
+	wrapped, ok := parsed.recipient(keyID)
+	if !ok {
+		return nil, utils.WithKind(utils.ErrInvalidKey, errors.New("envelope has no recipient "+keyID))
+	}
+
+	// Unwrap the content key
+	wrapGCM, err := h.newGCM(recipientKey, nil, parsed.EphemeralPublicKey, parsed.Nonce, wrapInfo, []byte(keyID))
+	if err != nil {
+		return nil, errors.Wrap(err, "[DecryptMultiWithKey][newGCM]")
+	}
+	contentKey, err := wrapGCM.Open(nil, iv(parsed.Nonce), wrapped.WrappedKey, []byte(keyID))
+	if err != nil {
+		return nil, errors.Wrap(utils.WithKind(utils.ErrDecryptionFailed, err), "[DecryptMultiWithKey][wrapGCM.Open]")
+	}
+	contentSecret := utils.NewSecret(contentKey)
+	defer contentSecret.Destroy()
+
+	// Decrypt the payload
+	contentGCM, err := newAESGCM(contentSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "[DecryptMultiWithKey][newAESGCM]")
+	}
+	plaintext, err := contentGCM.Open(nil, iv(parsed.Nonce), parsed.Ciphertext, parsed.header())
+	if err != nil {
+		return nil, errors.Wrap(utils.WithKind(utils.ErrDecryptionFailed, err), "[DecryptMultiWithKey][contentGCM.Open]")
+	}
+	return plaintext, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 Recipients                                 */
+/* -------------------------------------------------------------------------- */
+// returns the key IDs a base64 encoded multi-recipient envelope is addressed
+// to, so a service holding several keys can pick the right one.
+func (h *eciesHandler) Recipients(envelope string) ([]string, error) {
+	b, err := utils.DecodeBase64(envelope)
+	if err != nil {
+		return nil, utils.WithKind(utils.ErrInvalidInput, err)
+	}
+	parsed, err := ParseMultiEnvelope(b, h.Curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Recipients][ParseMultiEnvelope]")
+	}
+	keyIDs := make([]string, len(parsed.Recipients))
+	for i, recipient := range parsed.Recipients {
+		keyIDs[i] = recipient.KeyID
+	}
+	return keyIDs, nil
+}
@@ -0,0 +1,112 @@
+package ecies
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                   Tests for EncryptMulti and DecryptMulti                  */
+/* -------------------------------------------------------------------------- */
+func TestEncryptMulti(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	generator := keypairgen.Handler(BC25519)
+	var keys []*keypairgen.KeyMaterial
+	for i := 0; i < 3; i++ {
+		keyMaterial, err := generator.Generate()
+		assert.NoError(t, err)
+		keys = append(keys, keyMaterial)
+	}
+	plaintext := bytes.Repeat([]byte(`{"resourceType":"Bundle"}`), 100)
+
+	envelope, err := handler.EncryptMulti([]Recipient{
+		{KeyID: "hiu-1", PublicKey: keys[0].PublicKey},
+		{KeyID: "hiu-2", PublicKey: keys[1].X509PublicKey},
+		{PublicKey: keys[2].PublicKey},
+	}, plaintext)
+	assert.NoError(t, err)
+
+	fingerprint, err := handler.Fingerprint(keys[2].X509PublicKey)
+	assert.NoError(t, err)
+	keyIDs, err := handler.Recipients(envelope)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hiu-1", "hiu-2", fingerprint}, keyIDs)
+
+	for i, keyID := range []string{"hiu-1", "hiu-2", ""} {
+		decrypted, err := handler.DecryptMulti(keyID, keys[i].PrivateKey, envelope)
+		assert.NoError(t, err, i)
+		assert.Equal(t, plaintext, decrypted)
+	}
+
+	// the payload is stored once, not once per recipient
+	b, err := utils.DecodeBase64(envelope)
+	assert.NoError(t, err)
+	assert.Less(t, len(b), len(plaintext)+400)
+}
+
+func TestDecryptMultiRejects(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	generator := keypairgen.Handler(BC25519)
+	alice, err := generator.Generate()
+	assert.NoError(t, err)
+	bob, err := generator.Generate()
+	assert.NoError(t, err)
+	envelope, err := handler.EncryptMulti([]Recipient{
+		{KeyID: "alice", PublicKey: alice.PublicKey},
+		{KeyID: "bob", PublicKey: bob.PublicKey},
+	}, []byte("hello"))
+	assert.NoError(t, err)
+
+	// a key ID that isn't in the envelope, or another recipient's key
+	_, err = handler.DecryptMulti("carol", alice.PrivateKey, envelope)
+	assert.Equal(t, utils.ErrInvalidKey, utils.KindOf(err))
+	_, err = handler.DecryptMulti("bob", alice.PrivateKey, envelope)
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+
+	// removing a recipient from the authenticated list is detected
+	b, err := utils.DecodeBase64(envelope)
+	assert.NoError(t, err)
+	parsed, err := ParseMultiEnvelope(b, BC25519)
+	assert.NoError(t, err)
+	parsed.Recipients = parsed.Recipients[:1]
+	_, err = handler.DecryptMulti("alice", alice.PrivateKey, utils.EncodeBase64(parsed.Marshal()))
+	assert.Equal(t, utils.ErrDecryptionFailed, utils.KindOf(err))
+
+	// single-recipient envelopes are a different format
+	single, err := handler.Encrypt(alice.PublicKey, []byte("hello"))
+	assert.NoError(t, err)
+	_, err = handler.DecryptMulti("alice", alice.PrivateKey, single)
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+	_, err = handler.Decrypt(alice.PrivateKey, envelope)
+	assert.ErrorIs(t, err, ErrInvalidEnvelope)
+}
+
+func TestEncryptMultiRejects(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	alice, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+
+	_, err = handler.EncryptMulti(nil, []byte("hello"))
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+	_, err = handler.EncryptMulti([]Recipient{
+		{KeyID: "alice", PublicKey: alice.PublicKey},
+		{KeyID: "alice", PublicKey: alice.PublicKey},
+	}, []byte("hello"))
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+	_, err = handler.EncryptMulti([]Recipient{
+		{KeyID: string(make([]byte, 256)), PublicKey: alice.PublicKey},
+	}, []byte("hello"))
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+	_, err = handler.EncryptMulti([]Recipient{{KeyID: "alice", PublicKey: "AAAA"}}, []byte("hello"))
+	assert.Equal(t, utils.ErrInvalidKey, utils.KindOf(err))
+}
@@ -0,0 +1,39 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/zoop/fidelius-go/internal/workpool"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                EncryptBatch                                */
+/* -------------------------------------------------------------------------- */
+// encrypts reqs on a pool of WithWorkers goroutines and returns one result
+// per request, in order. The ECDH shared secret is computed once for each
+// distinct SenderPrivateKey and RequesterPublicKey pair; requests with a
+// SenderKey are not deduplicated. When ctx is done no further requests are
+// started and requests in flight stop as in EncryptContext: their results
+// carry ctx.Err(), which is also returned.
+func (cc *encryptionHandler) EncryptBatch(ctx context.Context, reqs []EncryptionRequest) ([]BatchResult, error) {
+	results := make([]BatchResult, len(reqs))
+	var secrets workpool.SecretCache
+	defer secrets.Destroy()
+
+	sharedSecret := func(req EncryptionRequest) (*utils.Secret, error) {
+		if req.SenderKey != nil {
+			return cc.sharedSecret(req)
+		}
+		// Base64 never contains NUL, so the key is unambiguous
+		return secrets.Get(req.SenderPrivateKey+"\x00"+req.RequesterPublicKey, func() (*utils.Secret, error) {
+			return cc.sharedSecret(req)
+		})
+	}
+	err := workpool.Run(ctx, len(reqs), cc.Workers, func(i int) {
+		results[i].EncryptedData, results[i].Err = cc.encrypt(ctx, reqs[i], sharedSecret)
+	}, func(i int) {
+		results[i].Err = ctx.Err()
+	})
+	return results, err
+}
@@ -0,0 +1,83 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                           Tests for EncryptBatch                           */
+/* -------------------------------------------------------------------------- */
+func TestEncryptBatch(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	generator := keypairgen.Handler(BC25519)
+	sender, err := generator.Generate()
+	assert.NoError(t, err)
+	var requesters []*keypairgen.KeyMaterial
+	for i := 0; i < 3; i++ {
+		requester, err := generator.Generate()
+		assert.NoError(t, err)
+		requesters = append(requesters, requester)
+	}
+
+	var reqs []EncryptionRequest
+	for i := 0; i < 30; i++ {
+		requester := requesters[i%len(requesters)]
+		reqs = append(reqs, EncryptionRequest{
+			StringToEncrypt:    fmt.Sprintf("entry %d", i),
+			SenderNonce:        utils.GenerateBase64Nonce(),
+			RequesterNonce:     requester.Nonce,
+			SenderPrivateKey:   sender.PrivateKey,
+			RequesterPublicKey: requester.PublicKey,
+		})
+	}
+	reqs[7].RequesterPublicKey = "AAAA"
+
+	handler := Handler(BC25519, WithWorkers(4))
+	results, err := handler.EncryptBatch(context.Background(), reqs)
+	assert.NoError(t, err)
+	assert.Len(t, results, len(reqs))
+	for i, result := range results {
+		if i == 7 {
+			assert.Equal(t, utils.ErrInvalidKey, utils.KindOf(result.Err))
+			continue
+		}
+		// results are in order and match Encrypt
+		assert.NoError(t, result.Err, i)
+		expected, err := handler.Encrypt(reqs[i])
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result.EncryptedData, i)
+	}
+}
+
+func TestEncryptBatchCancelled(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	reqs := make([]EncryptionRequest, 5)
+	for i := range reqs {
+		reqs[i] = EncryptionRequest{
+			StringToEncrypt:    "entry",
+			SenderNonce:        keyMaterial.Nonce,
+			RequesterNonce:     keyMaterial.Nonce,
+			SenderPrivateKey:   keyMaterial.PrivateKey,
+			RequesterPublicKey: keyMaterial.PublicKey,
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := Handler(BC25519).EncryptBatch(ctx, reqs)
+	assert.ErrorIs(t, err, context.Canceled)
+	for _, result := range results {
+		assert.ErrorIs(t, result.Err, context.Canceled)
+		assert.Empty(t, result.EncryptedData)
+	}
+}
@@ -0,0 +1,101 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/zoop/fidelius-go/internal/bench"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                           Benchmarks for Encrypt                           */
+/* -------------------------------------------------------------------------- */
+func BenchmarkEncrypt(b *testing.B) {
+	BC25519, err := utils.GetBC25519Curve()
+	if err != nil {
+		b.Fatal(err)
+	}
+	sender, err := keypairgen.Handler(BC25519).Generate()
+	if err != nil {
+		b.Fatal(err)
+	}
+	requester, err := keypairgen.Handler(BC25519).Generate()
+	if err != nil {
+		b.Fatal(err)
+	}
+	handler := Handler(BC25519)
+
+	for _, size := range bench.PayloadSizes {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			request := EncryptionRequest{
+				StringToEncrypt:    string(bytes.Repeat([]byte{'a'}, size)),
+				SenderNonce:        sender.Nonce,
+				RequesterNonce:     requester.Nonce,
+				SenderPrivateKey:   sender.PrivateKey,
+				RequesterPublicKey: requester.PublicKey,
+			}
+			b.SetBytes(int64(size))
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := handler.Encrypt(request); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         Benchmarks for EncryptBatch                        */
+/* -------------------------------------------------------------------------- */
+// compares a loop over Encrypt with EncryptBatch for entries of one data
+// flow, which share the key pair and so need only one ECDH.
+func BenchmarkEncryptBatch(b *testing.B) {
+	BC25519, err := utils.GetBC25519Curve()
+	if err != nil {
+		b.Fatal(err)
+	}
+	sender, err := keypairgen.Handler(BC25519).Generate()
+	if err != nil {
+		b.Fatal(err)
+	}
+	requester, err := keypairgen.Handler(BC25519).Generate()
+	if err != nil {
+		b.Fatal(err)
+	}
+	reqs := make([]EncryptionRequest, 64)
+	for i := range reqs {
+		reqs[i] = EncryptionRequest{
+			StringToEncrypt:    string(bytes.Repeat([]byte{'a'}, 1<<10)),
+			SenderNonce:        utils.GenerateBase64Nonce(),
+			RequesterNonce:     requester.Nonce,
+			SenderPrivateKey:   sender.PrivateKey,
+			RequesterPublicKey: requester.PublicKey,
+		}
+	}
+	handler := Handler(BC25519)
+
+	b.Run("loop", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, req := range reqs {
+				if _, err := handler.Encrypt(req); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := handler.EncryptBatch(context.Background(), reqs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package encryption
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// cancellingKey cancels a context while computing the shared secret, as if
+// the deadline passed during the scalar multiplication.
+type cancellingKey struct {
+	utils.ECDHKey
+	cancel context.CancelFunc
+}
+
+func (k *cancellingKey) SharedSecret(peerPublicKey *utils.Point) (*utils.Secret, error) {
+	k.cancel()
+	return k.ECDHKey.SharedSecret(peerPublicKey)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for EncryptContext                          */
+/* -------------------------------------------------------------------------- */
+func TestEncryptContext(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	req := EncryptionRequest{
+		StringToEncrypt:    "Hello, World!",
+		SenderNonce:        keyMaterial.Nonce,
+		RequesterNonce:     keyMaterial.Nonce,
+		SenderPrivateKey:   keyMaterial.PrivateKey,
+		RequesterPublicKey: keyMaterial.PublicKey,
+	}
+
+	expected, err := handler.Encrypt(req)
+	assert.NoError(t, err)
+	encrypted, err := handler.EncryptContext(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, encrypted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = handler.EncryptContext(ctx, req)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = handler.EncryptContext(ctx, req)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// cancellation during ECDH stops before HKDF
+	ctx, cancel = context.WithCancel(context.Background())
+	senderKey, err := utils.NewPrivateKeyECDH(BC25519, keyMaterial.PrivateKey)
+	assert.NoError(t, err)
+	defer senderKey.Destroy()
+	req.SenderKey = &cancellingKey{ECDHKey: senderKey, cancel: cancel}
+	_, err = handler.EncryptContext(ctx, req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
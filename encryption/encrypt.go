@@ -1,10 +1,13 @@
 package encryption
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"encoding/base64"
+	"errors"
 
+	"github.com/zoop/fidelius-go/observe"
 	"github.com/zoop/fidelius-go/utils"
 )
 
@@ -12,39 +15,103 @@ import (
 /*                                   Encrypt                                  */
 /* -------------------------------------------------------------------------- */
 func (cc *encryptionHandler) Encrypt(req EncryptionRequest) (string, error) {
+	return cc.EncryptContext(context.Background(), req)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               EncryptContext                               */
+/* -------------------------------------------------------------------------- */
+// is Encrypt that stops with ctx.Err() once ctx is done. Cancellation is
+// checked between the expensive steps (ECDH, HKDF, sealing); a step that
+// has started runs to completion.
+func (cc *encryptionHandler) EncryptContext(ctx context.Context, req EncryptionRequest) (string, error) {
+	return cc.encrypt(ctx, req, cc.sharedSecret)
+}
+
+// encrypt is EncryptContext with the shared secret computation passed in,
+// so that EncryptBatch can compute each shared secret once.
+func (cc *encryptionHandler) encrypt(ctx context.Context, req EncryptionRequest, sharedSecretFn func(EncryptionRequest) (*utils.Secret, error)) (_ string, err error) {
+	ctx, span := cc.span(ctx, "")
+	defer func() { span.End(err) }()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	// Decode base64 nonces
 	senderNonce, err := base64.StdEncoding.DecodeString(req.SenderNonce)
 	if err != nil {
-		return "", err
+		return "", utils.WithKind(utils.ErrInvalidInput, err)
 	}
 	requesterNonce, err := base64.StdEncoding.DecodeString(req.RequesterNonce)
 	if err != nil {
-		return "", err
+		return "", utils.WithKind(utils.ErrInvalidInput, err)
 	}
 
 	// XOR nonces to generate IV and salt
 	xorOfNonces, err := utils.XORBytes(senderNonce, requesterNonce)
 	if err != nil {
-		return "", err
+		return "", utils.WithKind(utils.ErrInvalidInput, err)
+	}
+	if len(xorOfNonces) < 20 {
+		return "", utils.WithKind(utils.ErrInvalidInput, errors.New("nonces must be at least 20 bytes"))
 	}
 
 	iv := xorOfNonces[len(xorOfNonces)-12:] // Last 12 bytes for IV
 	salt := xorOfNonces[:20]                // First 20 bytes for salt
 
+	// Pick the plaintext, preferring the base64 form when given
+	plaintext := []byte(req.StringToEncrypt)
+	if req.StringToEncryptBase64 != nil {
+		plaintext, err = base64.StdEncoding.DecodeString(*req.StringToEncryptBase64)
+		if err != nil {
+			return "", utils.WithKind(utils.ErrInvalidInput, err)
+		}
+	}
+
+	plaintextSize := len(plaintext) // reported to hooks before compression
+
+	// Compress the plaintext behind an envelope header if enabled
+	var header []byte
+	if cc.Compression != utils.NoCompression {
+		if int64(len(plaintext)) > cc.maxDecompressedSize() {
+			return "", utils.WithKind(utils.ErrInvalidInput, utils.ErrDecompressedTooLarge)
+		}
+		_, stage := cc.span(ctx, observe.StageCompress)
+		plaintext, err = utils.Compress(cc.Compression, plaintext)
+		stage.End(err)
+		if err != nil {
+			return "", err
+		}
+		header = utils.EnvelopeHeader{Compression: cc.Compression}.Marshal()
+	}
+
 	// Compute the shared secret
-	sharedSecret, err := utils.ComputeSharedSecret(req.SenderPrivateKey, req.RequesterPublicKey, cc.Curve)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	_, stage := cc.span(ctx, observe.StageECDH)
+	sharedSecret, err := sharedSecretFn(req)
+	stage.End(err)
 	if err != nil {
 		return "", err
 	}
+	defer sharedSecret.Destroy()
 
 	// Derive the AES encryption key using HKDF
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	_, stage = cc.span(ctx, observe.StageHKDF)
 	aesEncryptionKey, err := utils.Sha256HKDF(salt, sharedSecret, 32)
+	stage.End(err)
 	if err != nil {
 		return "", err
 	}
+	defer aesEncryptionKey.Destroy()
 
 	// Create AES cipher block
-	block, err := aes.NewCipher(aesEncryptionKey)
+	block, err := aes.NewCipher(aesEncryptionKey.Bytes())
 	if err != nil {
 		return "", err
 	}
@@ -55,10 +122,44 @@ func (cc *encryptionHandler) Encrypt(req EncryptionRequest) (string, error) {
 		return "", err
 	}
 
-	// Encrypt the data
-	plaintext := []byte(req.StringToEncrypt)
-	ciphertext := aesGCM.Seal(nil, iv, plaintext, nil)
+	// Encrypt the data after the header and key commitment tag, if any. The
+	// header is authenticated as additional data.
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	_, stage = cc.span(ctx, observe.StageSeal)
+	ciphertext := append([]byte(nil), header...)
+	if cc.KeyCommitment {
+		commitment, err := utils.KeyCommitment(salt, sharedSecret)
+		if err != nil {
+			stage.End(err)
+			return "", err
+		}
+		ciphertext = append(ciphertext, commitment...)
+	}
+	ciphertext = aesGCM.Seal(ciphertext, iv, plaintext, header)
+	stage.End(nil)
+	span.SetSizes(plaintextSize, len(ciphertext))
 
 	// Return base64-encoded ciphertext (data + tag)
 	return base64.StdEncoding.EncodeToString(ciphertext), nil
 }
+
+// sharedSecret computes the ECDH shared secret of the sender key and the
+// requester public key.
+func (cc *encryptionHandler) sharedSecret(req EncryptionRequest) (*utils.Secret, error) {
+	senderKey := req.SenderKey
+	if senderKey == nil {
+		privateKey, destroy, err := utils.NewECDHKey(cc.Curve, req.SenderPrivateKey, cc.X25519)
+		if err != nil {
+			return nil, err
+		}
+		defer destroy()
+		senderKey = privateKey
+	}
+	requesterPublicKey, err := utils.DecodeBase64ToPublicKey(req.RequesterPublicKey, cc.Curve)
+	if err != nil {
+		return nil, err
+	}
+	return senderKey.SharedSecret(requesterPublicKey)
+}
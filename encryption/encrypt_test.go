@@ -1,8 +1,12 @@
 package encryption
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"testing"
 
+	"github.com/zoop/fidelius-go/internal/secretwatch"
+	"github.com/zoop/fidelius-go/internal/testvectors"
 	"github.com/zoop/fidelius-go/keypairgen"
 	"github.com/zoop/fidelius-go/utils"
 
@@ -52,3 +56,134 @@ func TestEncrypt(t *testing.T) {
 	assert.NotEmpty(t, response)
 	assert.NotEmpty(t, response)
 }
+
+/* -------------------------------------------------------------------------- */
+/*                     Tests for Encrypt with base64 input                    */
+/* -------------------------------------------------------------------------- */
+
+func TestEncryptBase64Plaintext(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	request := EncryptionRequest{
+		StringToEncrypt:    "Hello, World!",
+		SenderNonce:        keyMaterial.Nonce,
+		RequesterNonce:     keyMaterial.Nonce,
+		SenderPrivateKey:   keyMaterial.PrivateKey,
+		RequesterPublicKey: keyMaterial.PublicKey,
+	}
+	expected, err := handler.Encrypt(request)
+	assert.NoError(t, err)
+
+	// the base64 form encrypts the same bytes and takes precedence
+	encoded := base64.StdEncoding.EncodeToString([]byte("Hello, World!"))
+	withBase64 := request
+	withBase64.StringToEncrypt = "ignored"
+	withBase64.StringToEncryptBase64 = &encoded
+	encrypted, err := handler.Encrypt(withBase64)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, encrypted)
+
+	// bytes that aren't valid UTF-8 survive, as the ciphertext length shows
+	binary := base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe, 0x00, 0x80})
+	withBase64.StringToEncryptBase64 = &binary
+	encrypted, err = handler.Encrypt(withBase64)
+	assert.NoError(t, err)
+	raw, err := base64.StdEncoding.DecodeString(encrypted)
+	assert.NoError(t, err)
+	assert.Len(t, raw, 4+16)
+
+	invalid := "not base64!"
+	withBase64.StringToEncryptBase64 = &invalid
+	_, err = handler.Encrypt(withBase64)
+	assert.ErrorIs(t, err, utils.ErrInvalidInput)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Tests for Encrypt zeroization                        */
+/* -------------------------------------------------------------------------- */
+
+func TestEncryptWipesSecrets(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+
+	var buffers [][]byte
+	restore := secretwatch.Set(func(buf []byte) {
+		buffers = append(buffers, buf)
+	})
+	defer restore()
+
+	_, err = Handler(BC25519).Encrypt(EncryptionRequest{
+		StringToEncrypt:    "Hello, World!",
+		SenderNonce:        utils.GenerateRandomNonce(32),
+		RequesterNonce:     utils.GenerateRandomNonce(32),
+		SenderPrivateKey:   keyMaterial.PrivateKey,
+		RequesterPublicKey: keyMaterial.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	// shared secret and AES key
+	assert.Len(t, buffers, 2)
+	for _, buf := range buffers {
+		assert.NotEmpty(t, buf)
+		assert.Equal(t, make([]byte, len(buf)), buf)
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                        Known-answer tests for Encrypt                      */
+/* -------------------------------------------------------------------------- */
+func TestEncryptVectors(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	vectors, err := testvectors.Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			// ECDH is symmetric, so a vector without the sender's private
+			// key is checked by encrypting from the requester's side.
+			request := EncryptionRequest{
+				StringToEncrypt:    v.Plaintext,
+				SenderNonce:        v.SenderNonce,
+				RequesterNonce:     v.RequesterNonce,
+				SenderPrivateKey:   v.SenderPrivateKey,
+				RequesterPublicKey: v.RequesterPublicKey,
+			}
+			if v.SenderPrivateKey == "" {
+				request.SenderPrivateKey = v.RequesterPrivateKey
+				request.RequesterPublicKey = v.SenderPublicKey
+			}
+			encryptedData, err := Handler(BC25519).Encrypt(request)
+			assert.NoError(t, err)
+			assert.Equal(t, v.EncryptedData, encryptedData)
+
+			encryptedData, err = Handler(BC25519, WithX25519()).Encrypt(request)
+			assert.NoError(t, err)
+			assert.Equal(t, v.EncryptedData, encryptedData)
+		})
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Tests for EncryptionRequest JSON                     */
+/* -------------------------------------------------------------------------- */
+
+func TestEncryptionRequestJSON(t *testing.T) {
+	encoded := "AQID"
+	data, err := json.Marshal(EncryptionRequest{
+		SenderNonce:           "a",
+		RequesterNonce:        "b",
+		SenderPrivateKey:      "c",
+		RequesterPublicKey:    "d",
+		StringToEncrypt:       "e",
+		StringToEncryptBase64: &encoded,
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"senderNonce":"a","requesterNonce":"b","senderPrivateKey":"c","requesterPublicKey":"d","stringToEncrypt":"e","stringToEncryptBase64":"AQID"}`, string(data))
+}
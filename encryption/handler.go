@@ -1,17 +1,121 @@
 package encryption
 
-import "github.com/zoop/fidelius-go/utils"
+import (
+	"context"
+
+	"github.com/zoop/fidelius-go/observe"
+	"github.com/zoop/fidelius-go/utils"
+)
 
 /* -------------------------------------------------------------------------- */
 /*                              EncryptionHandler                             */
 /* -------------------------------------------------------------------------- */
 type encryptionHandler struct {
-	Curve *utils.Curve
+	Curve         *utils.Curve
+	X25519        bool
+	KeyCommitment bool
+	Compression   utils.Compression
+	// MaxDecompressedSize bounds plaintexts compressed with Compression;
+	// zero means utils.DefaultMaxDecompressedSize.
+	MaxDecompressedSize int64
+	Workers             int
+	Hook                observe.Hook
 }
 
-func Handler(curve *utils.Curve) *encryptionHandler {
+// Option configures optional behaviour of the encryption handler.
+type Option func(*encryptionHandler)
+
+func Handler(curve *utils.Curve, opts ...Option) *encryptionHandler {
 	controller := &encryptionHandler{
 		Curve: curve,
 	}
+	for _, opt := range opts {
+		opt(controller)
+	}
 	return controller
 }
+
+/* -------------------------------------------------------------------------- */
+/*                                 WithX25519                                 */
+/* -------------------------------------------------------------------------- */
+// computes shared secrets for SenderPrivateKey with crypto/ecdh's X25519
+// (see utils.NewX25519ECDH). The output is unchanged. BC25519 only.
+func WithX25519() Option {
+	return func(cc *encryptionHandler) {
+		cc.X25519 = true
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              WithKeyCommitment                             */
+/* -------------------------------------------------------------------------- */
+// prepends a key commitment tag (see utils.KeyCommitment) to the ciphertext,
+// so it can only be decrypted with the intended key. The output is no longer
+// Fidelius compatible; decrypt it with decryption.WithKeyCommitment.
+func WithKeyCommitment() Option {
+	return func(cc *encryptionHandler) {
+		cc.KeyCommitment = true
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               WithCompression                              */
+/* -------------------------------------------------------------------------- */
+// compresses the plaintext with c before sealing and records c in an
+// envelope header (see utils.EnvelopeHeader), which decryption handlers
+// detect and reverse automatically. The output is no longer Fidelius
+// compatible, and its length leaks how compressible the plaintext is (see
+// the readme on CRIME/BREACH-style attacks).
+func WithCompression(c utils.Compression) Option {
+	return func(cc *encryptionHandler) {
+		cc.Compression = c
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           WithMaxDecompressedSize                          */
+/* -------------------------------------------------------------------------- */
+// sets the largest plaintext WithCompression accepts. Larger ones fail with
+// utils.ErrDecompressedTooLarge instead of producing ciphertexts the
+// decrypting side would reject; use the same value as its
+// decryption.WithMaxDecompressedSize.
+func WithMaxDecompressedSize(n int64) Option {
+	return func(cc *encryptionHandler) {
+		cc.MaxDecompressedSize = n
+	}
+}
+
+func (cc *encryptionHandler) maxDecompressedSize() int64 {
+	if cc.MaxDecompressedSize > 0 {
+		return cc.MaxDecompressedSize
+	}
+	return utils.DefaultMaxDecompressedSize
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 WithWorkers                                */
+/* -------------------------------------------------------------------------- */
+// sets the number of requests EncryptBatch processes concurrently; the
+// default is runtime.NumCPU().
+func WithWorkers(n int) Option {
+	return func(cc *encryptionHandler) {
+		cc.Workers = n
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  WithHook                                  */
+/* -------------------------------------------------------------------------- */
+// reports every Encrypt, and each of its stages, to hook (see package
+// observe).
+func WithHook(hook observe.Hook) Option {
+	return func(cc *encryptionHandler) {
+		cc.Hook = hook
+	}
+}
+
+// span starts an observe span for stage of Encrypt, or for the whole
+// operation if stage is empty.
+func (cc *encryptionHandler) span(ctx context.Context, stage observe.Stage) (context.Context, *observe.Span) {
+	return observe.Start(ctx, cc.Hook, observe.Event{Operation: observe.Encrypt, Stage: stage, Curve: cc.Curve.Name})
+}
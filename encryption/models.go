@@ -1,10 +1,23 @@
 package encryption
 
+import "github.com/zoop/fidelius-go/utils"
+
 type EncryptionRequest struct {
-	SenderNonce           string
-	RequesterNonce        string
-	SenderPrivateKey      string
-	RequesterPublicKey    string
-	StringToEncrypt       string
-	StringToEncryptBase64 *string
+	SenderNonce           string  `json:"senderNonce"`
+	RequesterNonce        string  `json:"requesterNonce"`
+	SenderPrivateKey      string  `json:"senderPrivateKey"`
+	RequesterPublicKey    string  `json:"requesterPublicKey"`
+	StringToEncrypt       string  `json:"stringToEncrypt"`
+	StringToEncryptBase64 *string `json:"stringToEncryptBase64,omitempty"`
+
+	// SenderKey, when set, is used instead of SenderPrivateKey so the
+	// private key never has to be loaded into this process.
+	SenderKey utils.ECDHKey `json:"-"`
+}
+
+// BatchResult is the outcome of one EncryptBatch request. Err is set when
+// the request failed.
+type BatchResult struct {
+	EncryptedData string
+	Err           error
 }
@@ -0,0 +1,80 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/observe"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                             Tests for WithHook                             */
+/* -------------------------------------------------------------------------- */
+func TestEncryptHook(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+
+	var logs bytes.Buffer
+	metrics := observe.NewMetrics()
+	tracer := observe.NewMemoryTracer()
+	hook := observe.Multi(
+		observe.Logger(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		metrics,
+		observe.Tracing(tracer),
+	)
+	handler := Handler(BC25519, WithHook(hook), WithCompression(utils.Gzip))
+
+	plaintext := strings.Repeat("Hello, World! ", 10)
+	req := EncryptionRequest{
+		StringToEncrypt:    plaintext,
+		SenderNonce:        keyMaterial.Nonce,
+		RequesterNonce:     keyMaterial.Nonce,
+		SenderPrivateKey:   keyMaterial.PrivateKey,
+		RequesterPublicKey: keyMaterial.PublicKey,
+	}
+	encrypted, err := handler.Encrypt(req)
+	assert.NoError(t, err)
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted)
+	assert.NoError(t, err)
+
+	spans := tracer.Spans()
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, []string{
+		"fidelius.encrypt.compress",
+		"fidelius.encrypt.ecdh",
+		"fidelius.encrypt.hkdf",
+		"fidelius.encrypt.seal",
+		"fidelius.encrypt",
+	}, names)
+	size, _ := spans[4].Attribute("fidelius.plaintext_size")
+	assert.Equal(t, int64(len(plaintext)), size.Int64())
+	size, _ = spans[4].Attribute("fidelius.ciphertext_size")
+	assert.Equal(t, int64(len(ciphertext)), size.Int64())
+
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricOperations, "encrypt", BC25519.Name, "OK"))
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricStageDuration, "encrypt", "ecdh"))
+
+	// invalid requests are counted by error code
+	bad := req
+	bad.SenderNonce = "not base64"
+	_, err = handler.Encrypt(bad)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricOperations, "encrypt", BC25519.Name, "INVALID_INPUT"))
+
+	// nothing secret or payload related is ever logged
+	for _, secret := range []string{keyMaterial.PrivateKey, keyMaterial.Nonce, encrypted, "Hello"} {
+		assert.NotContains(t, logs.String(), secret)
+	}
+	assert.Contains(t, logs.String(), `"msg":"fidelius encrypt hkdf"`)
+}
@@ -0,0 +1,245 @@
+// Package client is a typed client for the Fidelius gRPC API that speaks in
+// the library's own request types and error kinds.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/grpcapi/fideliuspb"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type Client struct {
+	rpc fideliuspb.FideliusClient
+}
+
+// Result is one streamed result. Err carries the item's error, tagged with
+// the same kind the server reported.
+type Result struct {
+	Index int
+	Data  string
+	Err   error
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                     New                                    */
+/* -------------------------------------------------------------------------- */
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{rpc: fideliuspb.NewFideliusClient(conn)}
+}
+
+func (c *Client) GenerateKeyMaterial(ctx context.Context) (*keypairgen.KeyMaterial, error) {
+	resp, err := c.rpc.GenerateKeyMaterial(ctx, &fideliuspb.GenerateKeyMaterialRequest{})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return &keypairgen.KeyMaterial{
+		PrivateKey:    resp.GetPrivateKey(),
+		PublicKey:     resp.GetPublicKey(),
+		X509PublicKey: resp.GetX509PublicKey(),
+		Nonce:         resp.GetNonce(),
+	}, nil
+}
+
+func (c *Client) Encrypt(ctx context.Context, req encryption.EncryptionRequest) (string, error) {
+	pbReq, err := toEncryptRequest(req)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.rpc.Encrypt(ctx, pbReq)
+	if err != nil {
+		return "", fromStatus(err)
+	}
+	return resp.GetEncryptedData(), nil
+}
+
+func (c *Client) Decrypt(ctx context.Context, req decryption.DecryptionRequest) (string, error) {
+	resp, err := c.rpc.Decrypt(ctx, toDecryptRequest(req))
+	if err != nil {
+		return "", fromStatus(err)
+	}
+	return string(resp.GetData()), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                EncryptStream                               */
+/* -------------------------------------------------------------------------- */
+// encrypts all requests over one stream and returns the results in order.
+// Requests are checked before the stream is opened, so one that can't be
+// sent (e.g. with a SenderKey) fails the call without sending anything.
+func (c *Client) EncryptStream(ctx context.Context, reqs []encryption.EncryptionRequest) ([]Result, error) {
+	pbReqs := make([]*fideliuspb.EncryptRequest, len(reqs))
+	for i, req := range reqs {
+		pbReq, err := toEncryptRequest(req)
+		if err != nil {
+			return nil, fmt.Errorf("request %d: %w", i, err)
+		}
+		pbReqs[i] = pbReq
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.rpc.EncryptStream(ctx)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	sendErr := make(chan error, 1)
+	go func() {
+		for _, pbReq := range pbReqs {
+			if err := stream.Send(pbReq); err != nil {
+				// stop the receive loop too, it would wait for io.EOF
+				sendErr <- err
+				cancel()
+				return
+			}
+		}
+		sendErr <- stream.CloseSend()
+	}()
+
+	results := make([]Result, 0, len(reqs))
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return results, receiveError(err, sendErr)
+		}
+		result := Result{Index: int(resp.GetIndex())}
+		if streamErr := resp.GetError(); streamErr != nil {
+			result.Err = fromStreamError(streamErr)
+		} else {
+			result.Data = resp.GetResponse().GetEncryptedData()
+		}
+		results = append(results, result)
+	}
+	return results, <-sendErr
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                DecryptStream                               */
+/* -------------------------------------------------------------------------- */
+// decrypts all requests over one stream and returns the results in order.
+func (c *Client) DecryptStream(ctx context.Context, reqs []decryption.DecryptionRequest) ([]Result, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.rpc.DecryptStream(ctx)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	sendErr := make(chan error, 1)
+	go func() {
+		for _, req := range reqs {
+			if err := stream.Send(toDecryptRequest(req)); err != nil {
+				sendErr <- err
+				cancel()
+				return
+			}
+		}
+		sendErr <- stream.CloseSend()
+	}()
+
+	results := make([]Result, 0, len(reqs))
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return results, receiveError(err, sendErr)
+		}
+		result := Result{Index: int(resp.GetIndex())}
+		if streamErr := resp.GetError(); streamErr != nil {
+			result.Err = fromStreamError(streamErr)
+		} else {
+			result.Data = string(resp.GetResponse().GetData())
+		}
+		results = append(results, result)
+	}
+	return results, <-sendErr
+}
+
+// receiveError prefers a send error, which cancels the stream, over the
+// resulting receive error.
+func receiveError(err error, sendErr <-chan error) error {
+	select {
+	case sendErr := <-sendErr:
+		if sendErr != nil {
+			return sendErr
+		}
+	default:
+	}
+	return fromStatus(err)
+}
+
+func toEncryptRequest(req encryption.EncryptionRequest) (*fideliuspb.EncryptRequest, error) {
+	if req.SenderKey != nil {
+		return nil, errors.New("SenderKey can't be sent over gRPC; use SenderPrivateKey")
+	}
+	data := []byte(req.StringToEncrypt)
+	if req.StringToEncryptBase64 != nil {
+		decoded, err := utils.DecodeBase64(*req.StringToEncryptBase64)
+		if err != nil {
+			return nil, utils.WithKind(utils.ErrInvalidInput, err)
+		}
+		data = decoded
+	}
+	return &fideliuspb.EncryptRequest{
+		SenderNonce:        req.SenderNonce,
+		RequesterNonce:     req.RequesterNonce,
+		SenderPrivateKey:   req.SenderPrivateKey,
+		RequesterPublicKey: req.RequesterPublicKey,
+		Data:               data,
+	}, nil
+}
+
+func toDecryptRequest(req decryption.DecryptionRequest) *fideliuspb.DecryptRequest {
+	return &fideliuspb.DecryptRequest{
+		SenderNonce:         req.SenderNonce,
+		RequesterNonce:      req.RequesterNonce,
+		RequesterPrivateKey: req.RequesterPrivateKey,
+		SenderPublicKey:     req.SenderPublicKey,
+		EncryptedData:       req.EncryptedData,
+	}
+}
+
+// kinds maps error codes back onto the library's error kinds.
+var kinds = map[string]error{
+	"INVALID_INPUT":     utils.ErrInvalidInput,
+	"INVALID_KEY":       utils.ErrInvalidKey,
+	"DECRYPTION_FAILED": utils.ErrDecryptionFailed,
+}
+
+// fromStatus restores the error kind from the status' ErrorInfo detail so
+// callers can use errors.Is with the utils error kinds.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			if kind, ok := kinds[info.GetReason()]; ok {
+				return utils.WithKind(kind, err)
+			}
+		}
+	}
+	return err
+}
+
+func fromStreamError(streamErr *fideliuspb.StreamError) error {
+	err := errors.New(streamErr.GetMessage())
+	if kind, ok := kinds[streamErr.GetCode()]; ok {
+		return utils.WithKind(kind, err)
+	}
+	return err
+}
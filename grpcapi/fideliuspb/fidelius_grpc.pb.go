@@ -0,0 +1,280 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: fidelius/v1/fidelius.proto
+
+package fideliuspb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Fidelius_GenerateKeyMaterial_FullMethodName = "/fidelius.v1.Fidelius/GenerateKeyMaterial"
+	Fidelius_Encrypt_FullMethodName             = "/fidelius.v1.Fidelius/Encrypt"
+	Fidelius_Decrypt_FullMethodName             = "/fidelius.v1.Fidelius/Decrypt"
+	Fidelius_EncryptStream_FullMethodName       = "/fidelius.v1.Fidelius/EncryptStream"
+	Fidelius_DecryptStream_FullMethodName       = "/fidelius.v1.Fidelius/DecryptStream"
+)
+
+// FideliusClient is the client API for Fidelius service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Fidelius exposes ABDM Fidelius key generation, encryption and decryption.
+//
+// Unary calls report failures as gRPC status errors carrying a
+// google.rpc.ErrorInfo detail whose reason is one of INVALID_INPUT,
+// INVALID_KEY, DECRYPTION_FAILED or INTERNAL. Streaming calls report
+// failures per item and keep the stream open.
+type FideliusClient interface {
+	GenerateKeyMaterial(ctx context.Context, in *GenerateKeyMaterialRequest, opts ...grpc.CallOption) (*KeyMaterial, error)
+	Encrypt(ctx context.Context, in *EncryptRequest, opts ...grpc.CallOption) (*EncryptResponse, error)
+	Decrypt(ctx context.Context, in *DecryptRequest, opts ...grpc.CallOption) (*DecryptResponse, error)
+	// EncryptStream answers every request in order with one response.
+	EncryptStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EncryptRequest, EncryptStreamResponse], error)
+	// DecryptStream answers every request in order with one response.
+	DecryptStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[DecryptRequest, DecryptStreamResponse], error)
+}
+
+type fideliusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFideliusClient(cc grpc.ClientConnInterface) FideliusClient {
+	return &fideliusClient{cc}
+}
+
+func (c *fideliusClient) GenerateKeyMaterial(ctx context.Context, in *GenerateKeyMaterialRequest, opts ...grpc.CallOption) (*KeyMaterial, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeyMaterial)
+	err := c.cc.Invoke(ctx, Fidelius_GenerateKeyMaterial_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fideliusClient) Encrypt(ctx context.Context, in *EncryptRequest, opts ...grpc.CallOption) (*EncryptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EncryptResponse)
+	err := c.cc.Invoke(ctx, Fidelius_Encrypt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fideliusClient) Decrypt(ctx context.Context, in *DecryptRequest, opts ...grpc.CallOption) (*DecryptResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecryptResponse)
+	err := c.cc.Invoke(ctx, Fidelius_Decrypt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fideliusClient) EncryptStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[EncryptRequest, EncryptStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Fidelius_ServiceDesc.Streams[0], Fidelius_EncryptStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EncryptRequest, EncryptStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Fidelius_EncryptStreamClient = grpc.BidiStreamingClient[EncryptRequest, EncryptStreamResponse]
+
+func (c *fideliusClient) DecryptStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[DecryptRequest, DecryptStreamResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Fidelius_ServiceDesc.Streams[1], Fidelius_DecryptStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DecryptRequest, DecryptStreamResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Fidelius_DecryptStreamClient = grpc.BidiStreamingClient[DecryptRequest, DecryptStreamResponse]
+
+// FideliusServer is the server API for Fidelius service.
+// All implementations must embed UnimplementedFideliusServer
+// for forward compatibility.
+//
+// Fidelius exposes ABDM Fidelius key generation, encryption and decryption.
+//
+// Unary calls report failures as gRPC status errors carrying a
+// google.rpc.ErrorInfo detail whose reason is one of INVALID_INPUT,
+// INVALID_KEY, DECRYPTION_FAILED or INTERNAL. Streaming calls report
+// failures per item and keep the stream open.
+type FideliusServer interface {
+	GenerateKeyMaterial(context.Context, *GenerateKeyMaterialRequest) (*KeyMaterial, error)
+	Encrypt(context.Context, *EncryptRequest) (*EncryptResponse, error)
+	Decrypt(context.Context, *DecryptRequest) (*DecryptResponse, error)
+	// EncryptStream answers every request in order with one response.
+	EncryptStream(grpc.BidiStreamingServer[EncryptRequest, EncryptStreamResponse]) error
+	// DecryptStream answers every request in order with one response.
+	DecryptStream(grpc.BidiStreamingServer[DecryptRequest, DecryptStreamResponse]) error
+	mustEmbedUnimplementedFideliusServer()
+}
+
+// UnimplementedFideliusServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFideliusServer struct{}
+
+func (UnimplementedFideliusServer) GenerateKeyMaterial(context.Context, *GenerateKeyMaterialRequest) (*KeyMaterial, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateKeyMaterial not implemented")
+}
+func (UnimplementedFideliusServer) Encrypt(context.Context, *EncryptRequest) (*EncryptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Encrypt not implemented")
+}
+func (UnimplementedFideliusServer) Decrypt(context.Context, *DecryptRequest) (*DecryptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decrypt not implemented")
+}
+func (UnimplementedFideliusServer) EncryptStream(grpc.BidiStreamingServer[EncryptRequest, EncryptStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method EncryptStream not implemented")
+}
+func (UnimplementedFideliusServer) DecryptStream(grpc.BidiStreamingServer[DecryptRequest, DecryptStreamResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DecryptStream not implemented")
+}
+func (UnimplementedFideliusServer) mustEmbedUnimplementedFideliusServer() {}
+func (UnimplementedFideliusServer) testEmbeddedByValue()                  {}
+
+// UnsafeFideliusServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FideliusServer will
+// result in compilation errors.
+type UnsafeFideliusServer interface {
+	mustEmbedUnimplementedFideliusServer()
+}
+
+func RegisterFideliusServer(s grpc.ServiceRegistrar, srv FideliusServer) {
+	// If the following call pancis, it indicates UnimplementedFideliusServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Fidelius_ServiceDesc, srv)
+}
+
+func _Fidelius_GenerateKeyMaterial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateKeyMaterialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FideliusServer).GenerateKeyMaterial(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fidelius_GenerateKeyMaterial_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FideliusServer).GenerateKeyMaterial(ctx, req.(*GenerateKeyMaterialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fidelius_Encrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FideliusServer).Encrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fidelius_Encrypt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FideliusServer).Encrypt(ctx, req.(*EncryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fidelius_Decrypt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecryptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FideliusServer).Decrypt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Fidelius_Decrypt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FideliusServer).Decrypt(ctx, req.(*DecryptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Fidelius_EncryptStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FideliusServer).EncryptStream(&grpc.GenericServerStream[EncryptRequest, EncryptStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Fidelius_EncryptStreamServer = grpc.BidiStreamingServer[EncryptRequest, EncryptStreamResponse]
+
+func _Fidelius_DecryptStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FideliusServer).DecryptStream(&grpc.GenericServerStream[DecryptRequest, DecryptStreamResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Fidelius_DecryptStreamServer = grpc.BidiStreamingServer[DecryptRequest, DecryptStreamResponse]
+
+// Fidelius_ServiceDesc is the grpc.ServiceDesc for Fidelius service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Fidelius_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "fidelius.v1.Fidelius",
+	HandlerType: (*FideliusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateKeyMaterial",
+			Handler:    _Fidelius_GenerateKeyMaterial_Handler,
+		},
+		{
+			MethodName: "Encrypt",
+			Handler:    _Fidelius_Encrypt_Handler,
+		},
+		{
+			MethodName: "Decrypt",
+			Handler:    _Fidelius_Decrypt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "EncryptStream",
+			Handler:       _Fidelius_EncryptStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DecryptStream",
+			Handler:       _Fidelius_DecryptStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "fidelius/v1/fidelius.proto",
+}
@@ -0,0 +1,5 @@
+package grpcapi
+
+// fideliuspb is generated from proto/fidelius/v1/fidelius.proto with
+// protoc-gen-go v1.34.2 and protoc-gen-go-grpc v1.5.1.
+//go:generate protoc -I ../proto --go_out=fideliuspb --go_opt=paths=source_relative --go-grpc_out=fideliuspb --go-grpc_opt=paths=source_relative fidelius/v1/fidelius.proto
@@ -0,0 +1,185 @@
+// Package grpcapi serves the Fidelius gRPC API defined in
+// proto/fidelius/v1/fidelius.proto on top of the library handlers.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/grpcapi/fideliuspb"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is the ErrorInfo domain of errors returned by the server.
+const ErrorDomain = "fidelius-go"
+
+type server struct {
+	fideliuspb.UnimplementedFideliusServer
+
+	Curve *utils.Curve
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Server                                   */
+/* -------------------------------------------------------------------------- */
+// returns a FideliusServer backed by the keypairgen, encryption and
+// decryption handlers for curve.
+func Server(curve *utils.Curve) *server {
+	return &server{Curve: curve}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  Register                                  */
+/* -------------------------------------------------------------------------- */
+// registers the Fidelius service for curve on s.
+func Register(s grpc.ServiceRegistrar, curve *utils.Curve) {
+	fideliuspb.RegisterFideliusServer(s, Server(curve))
+}
+
+func (s *server) GenerateKeyMaterial(ctx context.Context, req *fideliuspb.GenerateKeyMaterialRequest) (*fideliuspb.KeyMaterial, error) {
+	keyMaterial, err := keypairgen.Handler(s.Curve).GenerateContext(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &fideliuspb.KeyMaterial{
+		PrivateKey:    keyMaterial.PrivateKey,
+		PublicKey:     keyMaterial.PublicKey,
+		X509PublicKey: keyMaterial.X509PublicKey,
+		Nonce:         keyMaterial.Nonce,
+	}, nil
+}
+
+func (s *server) Encrypt(ctx context.Context, req *fideliuspb.EncryptRequest) (*fideliuspb.EncryptResponse, error) {
+	resp, err := s.encrypt(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func (s *server) Decrypt(ctx context.Context, req *fideliuspb.DecryptRequest) (*fideliuspb.DecryptResponse, error) {
+	resp, err := s.decrypt(ctx, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return resp, nil
+}
+
+func (s *server) EncryptStream(stream fideliuspb.Fidelius_EncryptStreamServer) error {
+	for index := uint64(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		out := &fideliuspb.EncryptStreamResponse{Index: index}
+		if resp, err := s.encrypt(stream.Context(), req); err != nil {
+			out.Result = &fideliuspb.EncryptStreamResponse_Error{Error: toStreamError(err)}
+		} else {
+			out.Result = &fideliuspb.EncryptStreamResponse_Response{Response: resp}
+		}
+		if err := stream.Send(out); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) DecryptStream(stream fideliuspb.Fidelius_DecryptStreamServer) error {
+	for index := uint64(0); ; index++ {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		out := &fideliuspb.DecryptStreamResponse{Index: index}
+		if resp, err := s.decrypt(stream.Context(), req); err != nil {
+			out.Result = &fideliuspb.DecryptStreamResponse_Error{Error: toStreamError(err)}
+		} else {
+			out.Result = &fideliuspb.DecryptStreamResponse_Response{Response: resp}
+		}
+		if err := stream.Send(out); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *server) encrypt(ctx context.Context, req *fideliuspb.EncryptRequest) (resp *fideliuspb.EncryptResponse, err error) {
+	defer recoverError(&err)
+	data := utils.EncodeBase64(req.GetData())
+	encryptedData, err := encryption.Handler(s.Curve).EncryptContext(ctx, encryption.EncryptionRequest{
+		SenderNonce:           req.GetSenderNonce(),
+		RequesterNonce:        req.GetRequesterNonce(),
+		SenderPrivateKey:      req.GetSenderPrivateKey(),
+		RequesterPublicKey:    req.GetRequesterPublicKey(),
+		StringToEncryptBase64: &data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &fideliuspb.EncryptResponse{EncryptedData: encryptedData}, nil
+}
+
+func (s *server) decrypt(ctx context.Context, req *fideliuspb.DecryptRequest) (resp *fideliuspb.DecryptResponse, err error) {
+	defer recoverError(&err)
+	decryptedData, err := decryption.Handler(s.Curve).DecryptContext(ctx, decryption.DecryptionRequest{
+		SenderNonce:         req.GetSenderNonce(),
+		RequesterNonce:      req.GetRequesterNonce(),
+		RequesterPrivateKey: req.GetRequesterPrivateKey(),
+		SenderPublicKey:     req.GetSenderPublicKey(),
+		EncryptedData:       req.GetEncryptedData(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &fideliuspb.DecryptResponse{Data: []byte(decryptedData)}, nil
+}
+
+// recoverError turns a handler panic into an internal error so one bad
+// request can't take the server down.
+func recoverError(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("request processing panicked: %v", r)
+	}
+}
+
+// toStatus converts a library error into a status with an ErrorInfo detail.
+// Errors without a kind are reported without details, and context errors
+// become Canceled or DeadlineExceeded.
+func toStatus(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	code, message := codes.InvalidArgument, err.Error()
+	switch utils.KindOf(err) {
+	case utils.ErrDecryptionFailed:
+		message = "decryption failed"
+	case nil:
+		code, message = codes.Internal, "internal error"
+	}
+	st, detailErr := status.New(code, message).WithDetails(&errdetails.ErrorInfo{
+		Reason: utils.ErrorCode(err),
+		Domain: ErrorDomain,
+	})
+	if detailErr != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
+
+func toStreamError(err error) *fideliuspb.StreamError {
+	st := status.Convert(toStatus(err))
+	return &fideliuspb.StreamError{Code: utils.ErrorCode(err), Message: st.Message()}
+}
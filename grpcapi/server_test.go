@@ -0,0 +1,231 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/grpcapi/client"
+	"github.com/zoop/fidelius-go/grpcapi/fideliuspb"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dial starts an in-process server on a bufconn listener and returns a
+// connection to it.
+func dial(t *testing.T) *grpc.ClientConn {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+
+	ln := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	Register(grpcServer, BC25519)
+	go grpcServer.Serve(ln)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return ln.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func keyPairs(t *testing.T, c *client.Client) (sender, requester *keypairgen.KeyMaterial) {
+	sender, err := c.GenerateKeyMaterial(context.Background())
+	assert.NoError(t, err)
+	requester, err = c.GenerateKeyMaterial(context.Background())
+	assert.NoError(t, err)
+	return sender, requester
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Tests for Server                              */
+/* -------------------------------------------------------------------------- */
+func TestEncryptDecrypt(t *testing.T) {
+	c := client.New(dial(t))
+	sender, requester := keyPairs(t, c)
+	assert.NotEmpty(t, sender.X509PublicKey)
+
+	encryptedData, err := c.Encrypt(context.Background(), encryption.EncryptionRequest{
+		StringToEncrypt:    "Hello, World!",
+		SenderNonce:        sender.Nonce,
+		RequesterNonce:     requester.Nonce,
+		SenderPrivateKey:   sender.PrivateKey,
+		RequesterPublicKey: requester.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	decryptedData, err := c.Decrypt(context.Background(), decryption.DecryptionRequest{
+		EncryptedData:       encryptedData,
+		SenderNonce:         sender.Nonce,
+		RequesterNonce:      requester.Nonce,
+		RequesterPrivateKey: requester.PrivateKey,
+		SenderPublicKey:     sender.PublicKey,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!", decryptedData)
+}
+
+func TestErrors(t *testing.T) {
+	conn := dial(t)
+	c := client.New(conn)
+	sender, requester := keyPairs(t, c)
+
+	/* ------------------------------ Invalid input ----------------------------- */
+	_, err := c.Decrypt(context.Background(), decryption.DecryptionRequest{
+		EncryptedData:       "not base64",
+		SenderNonce:         sender.Nonce,
+		RequesterNonce:      requester.Nonce,
+		RequesterPrivateKey: requester.PrivateKey,
+		SenderPublicKey:     sender.PublicKey,
+	})
+	assert.True(t, errors.Is(err, utils.ErrInvalidInput))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	/* ---------------------------- Decryption failed --------------------------- */
+	_, err = c.Decrypt(context.Background(), decryption.DecryptionRequest{
+		EncryptedData:       "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+		SenderNonce:         sender.Nonce,
+		RequesterNonce:      requester.Nonce,
+		RequesterPrivateKey: requester.PrivateKey,
+		SenderPublicKey:     sender.PublicKey,
+	})
+	assert.True(t, errors.Is(err, utils.ErrDecryptionFailed))
+
+	/* ------------------------------- Error detail ----------------------------- */
+	_, err = fideliuspb.NewFideliusClient(conn).Encrypt(context.Background(), &fideliuspb.EncryptRequest{
+		SenderNonce:        sender.Nonce,
+		RequesterNonce:     requester.Nonce,
+		SenderPrivateKey:   sender.PrivateKey,
+		RequesterPublicKey: "not base64",
+	})
+	st := status.Convert(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	if assert.Len(t, st.Details(), 1) {
+		info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+		assert.True(t, ok)
+		assert.Equal(t, "INVALID_KEY", info.GetReason())
+		assert.Equal(t, ErrorDomain, info.GetDomain())
+	}
+}
+
+func TestContextErrors(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	req := &fideliuspb.EncryptRequest{
+		Data:               []byte("Hello, World!"),
+		SenderNonce:        keyMaterial.Nonce,
+		RequesterNonce:     keyMaterial.Nonce,
+		SenderPrivateKey:   keyMaterial.PrivateKey,
+		RequesterPublicKey: keyMaterial.PublicKey,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Server(BC25519).Encrypt(ctx, req)
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	_, err = Server(BC25519).GenerateKeyMaterial(ctx, &fideliuspb.GenerateKeyMaterialRequest{})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+
+	ctx, cancel = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err = Server(BC25519).Encrypt(ctx, req)
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+}
+
+func TestStreams(t *testing.T) {
+	c := client.New(dial(t))
+	sender, requester := keyPairs(t, c)
+
+	messages := []string{"first", "second", "third"}
+	var encReqs []encryption.EncryptionRequest
+	for _, message := range messages {
+		encReqs = append(encReqs, encryption.EncryptionRequest{
+			StringToEncrypt:    message,
+			SenderNonce:        sender.Nonce,
+			RequesterNonce:     requester.Nonce,
+			SenderPrivateKey:   sender.PrivateKey,
+			RequesterPublicKey: requester.PublicKey,
+		})
+	}
+	encResults, err := c.EncryptStream(context.Background(), encReqs)
+	assert.NoError(t, err)
+	assert.Len(t, encResults, len(messages))
+
+	var decReqs []decryption.DecryptionRequest
+	for _, result := range encResults {
+		assert.NoError(t, result.Err)
+		decReqs = append(decReqs, decryption.DecryptionRequest{
+			EncryptedData:       result.Data,
+			SenderNonce:         sender.Nonce,
+			RequesterNonce:      requester.Nonce,
+			RequesterPrivateKey: requester.PrivateKey,
+			SenderPublicKey:     sender.PublicKey,
+		})
+	}
+	// A bad item in the middle fails alone and doesn't end the stream.
+	bad := decReqs[0]
+	bad.EncryptedData = "not base64"
+	decReqs = append(decReqs[:1], append([]decryption.DecryptionRequest{bad}, decReqs[1:]...)...)
+
+	decResults, err := c.DecryptStream(context.Background(), decReqs)
+	assert.NoError(t, err)
+	if assert.Len(t, decResults, 4) {
+		assert.Equal(t, "first", decResults[0].Data)
+		assert.Equal(t, 1, decResults[1].Index)
+		assert.True(t, errors.Is(decResults[1].Err, utils.ErrInvalidInput))
+		assert.Equal(t, "second", decResults[2].Data)
+		assert.Equal(t, "third", decResults[3].Data)
+	}
+}
+
+func TestEncryptStreamInvalidRequest(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	c := client.New(dial(t))
+	sender, requester := keyPairs(t, c)
+	senderKey, err := utils.NewPrivateKeyECDH(BC25519, sender.PrivateKey)
+	assert.NoError(t, err)
+
+	valid := encryption.EncryptionRequest{
+		StringToEncrypt:    "first",
+		SenderNonce:        sender.Nonce,
+		RequesterNonce:     requester.Nonce,
+		SenderPrivateKey:   sender.PrivateKey,
+		RequesterPublicKey: requester.PublicKey,
+	}
+	withKey := valid
+	withKey.SenderKey = senderKey
+	invalid := "not base64!"
+	withBadBase64 := valid
+	withBadBase64.StringToEncryptBase64 = &invalid
+
+	// a request that can't be sent fails the call instead of hanging it
+	for _, bad := range []encryption.EncryptionRequest{withKey, withBadBase64} {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		results, err := c.EncryptStream(ctx, []encryption.EncryptionRequest{valid, bad, valid})
+		cancel()
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, results)
+	}
+	_, err = c.EncryptStream(context.Background(), []encryption.EncryptionRequest{valid, withBadBase64})
+	assert.ErrorIs(t, err, utils.ErrInvalidInput)
+}
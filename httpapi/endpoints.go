@@ -0,0 +1,109 @@
+package httpapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+func (s *server) handleKeys(w http.ResponseWriter, r *http.Request) {
+	keyMaterial, err := s.keyPairGen.GenerateContext(r.Context())
+	if err != nil {
+		writeLibraryError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, keyMaterial)
+}
+
+func (s *server) handleEncrypt(w http.ResponseWriter, r *http.Request) {
+	var req encryption.EncryptionRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	encryptedData, err := s.encrypter.EncryptContext(r.Context(), req)
+	if err != nil {
+		writeLibraryError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, encryptionResponse{EncryptedData: encryptedData})
+}
+
+func (s *server) handleDecrypt(w http.ResponseWriter, r *http.Request) {
+	var req decryption.DecryptionRequest
+	if !s.readJSON(w, r, &req) {
+		return
+	}
+	decryptedData, err := s.decrypter.DecryptContext(r.Context(), req)
+	if err != nil {
+		writeLibraryError(w, err)
+		return
+	}
+	if r.URL.Query().Get("encoding") == "base64" || !utf8.ValidString(decryptedData) {
+		encoded := base64.StdEncoding.EncodeToString([]byte(decryptedData))
+		writeJSON(w, http.StatusOK, decryptionResponse{DecryptedDataBase64: &encoded})
+		return
+	}
+	writeJSON(w, http.StatusOK, decryptionResponse{DecryptedData: &decryptedData})
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ok"})
+}
+
+func (s *server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if s.Ready != nil {
+		if err := s.Ready(); err != nil {
+			// the check's error may name internal hosts or paths
+			s.Logger.Warn("readiness check failed", "error", err)
+			writeError(w, http.StatusServiceUnavailable, CodeNotReady, "not ready")
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, statusResponse{Status: "ready"})
+}
+
+// readJSON decodes the body into v, writing an error response on failure.
+func (s *server) readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, s.MaxBodySize)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, "request body too large")
+			return false
+		}
+		writeLibraryError(w, utils.WithKind(utils.ErrInvalidInput, errors.New("invalid JSON request: "+err.Error())))
+		return false
+	}
+	return true
+}
+
+// writeLibraryError maps the library's error kinds onto HTTP statuses.
+// Errors without a kind are reported without details.
+func writeLibraryError(w http.ResponseWriter, err error) {
+	code := utils.ErrorCode(err)
+	switch utils.KindOf(err) {
+	case utils.ErrInvalidInput, utils.ErrInvalidKey:
+		writeError(w, http.StatusBadRequest, code, err.Error())
+	case utils.ErrDecryptionFailed:
+		writeError(w, http.StatusUnprocessableEntity, code, "decryption failed")
+	default:
+		writeError(w, http.StatusInternalServerError, code, "internal error")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, ErrorResponse{Error: ErrorBody{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,165 @@
+package httpapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// DefaultMaxBodySize bounds request bodies unless WithMaxBodySize is used.
+const DefaultMaxBodySize = 10 << 20
+
+type server struct {
+	Curve       *utils.Curve
+	MaxBodySize int64
+	Ready       func() error
+	Logger      *slog.Logger
+
+	mux        *http.ServeMux
+	keyPairGen interface {
+		GenerateContext(context.Context) (*keypairgen.KeyMaterial, error)
+	}
+	encrypter interface {
+		EncryptContext(context.Context, encryption.EncryptionRequest) (string, error)
+	}
+	decrypter interface {
+		DecryptContext(context.Context, decryption.DecryptionRequest) (string, error)
+	}
+}
+
+// Option configures optional behaviour of the HTTP handler.
+type Option func(*server)
+
+/* -------------------------------------------------------------------------- */
+/*                                   Handler                                  */
+/* -------------------------------------------------------------------------- */
+// returns an http.Handler serving:
+//
+//	POST /keys     generate key material
+//	POST /encrypt  encrypt an EncryptionRequest
+//	POST /decrypt  decrypt a DecryptionRequest; add ?encoding=base64 for
+//	               binary plaintexts
+//	GET  /healthz  liveness
+//	GET  /readyz   readiness, see WithReadiness
+func Handler(curve *utils.Curve, opts ...Option) *server {
+	s := &server{
+		Curve:       curve,
+		MaxBodySize: DefaultMaxBodySize,
+		Logger:      slog.Default(),
+		keyPairGen:  keypairgen.Handler(curve),
+		encrypter:   encryption.Handler(curve),
+		decrypter:   decryption.Handler(curve),
+		mux:         http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mux.HandleFunc("/keys", s.method(http.MethodPost, s.handleKeys))
+	s.mux.HandleFunc("/encrypt", s.method(http.MethodPost, s.handleEncrypt))
+	s.mux.HandleFunc("/decrypt", s.method(http.MethodPost, s.handleDecrypt))
+	s.mux.HandleFunc("/healthz", s.method(http.MethodGet, s.handleHealth))
+	s.mux.HandleFunc("/readyz", s.method(http.MethodGet, s.handleReady))
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, CodeNotFound, "no such endpoint")
+	})
+	return s
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               WithMaxBodySize                              */
+/* -------------------------------------------------------------------------- */
+// limits request bodies to n bytes; larger requests get 413.
+func WithMaxBodySize(n int64) Option {
+	return func(s *server) {
+		s.MaxBodySize = n
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                WithReadiness                               */
+/* -------------------------------------------------------------------------- */
+// makes /readyz report 503 while check returns an error, e.g. while a key
+// store or remote key is unreachable. The response only carries the
+// NOT_READY code; the error is logged, see WithLogger.
+func WithReadiness(check func() error) Option {
+	return func(s *server) {
+		s.Ready = check
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 WithLogger                                 */
+/* -------------------------------------------------------------------------- */
+// logs failures whose details are kept out of responses, such as failed
+// readiness checks, to logger instead of slog.Default().
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *server) {
+		s.Logger = logger
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               WithDecryption                               */
+/* -------------------------------------------------------------------------- */
+// serves /decrypt with a preconfigured decryption handler, e.g. one
+// created with decryption.WithKeyStore.
+func WithDecryption(handler interface {
+	DecryptContext(context.Context, decryption.DecryptionRequest) (string, error)
+}) Option {
+	return func(s *server) {
+		s.decrypter = handler
+	}
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tw := &trackingWriter{ResponseWriter: w}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			// Once part of a response has gone out, a 500 can't replace it;
+			// abort the connection instead of appending to it.
+			if recovered == http.ErrAbortHandler || tw.wrote {
+				panic(http.ErrAbortHandler)
+			}
+			writeError(w, http.StatusInternalServerError, utils.ErrorCode(nil), "internal error")
+		}
+	}()
+	s.mux.ServeHTTP(tw, r)
+}
+
+// trackingWriter records whether a handler has started its response.
+type trackingWriter struct {
+	http.ResponseWriter
+	wrote bool
+}
+
+func (w *trackingWriter) WriteHeader(status int) {
+	w.wrote = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *trackingWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *trackingWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// method rejects requests that don't use the given HTTP method.
+func (s *server) method(method string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "use "+method)
+			return
+		}
+		next(w, r)
+	}
+}
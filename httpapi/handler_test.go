@@ -0,0 +1,208 @@
+package httpapi
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+func post(t *testing.T, server *httptest.Server, path string, body any) (*http.Response, []byte) {
+	payload, err := json.Marshal(body)
+	assert.NoError(t, err)
+	resp, err := http.Post(server.URL+path, "application/json", bytes.NewReader(payload))
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(resp.Body)
+	return resp, buf.Bytes()
+}
+
+func errorCode(t *testing.T, body []byte) string {
+	var errResp ErrorResponse
+	assert.NoError(t, json.Unmarshal(body, &errResp))
+	return errResp.Error.Code
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Tests for Handler                             */
+/* -------------------------------------------------------------------------- */
+func TestKeysEncryptDecrypt(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	server := httptest.NewServer(Handler(BC25519))
+	defer server.Close()
+
+	/* ---------------------------------- Keys ---------------------------------- */
+	var sender, requester keypairgen.KeyMaterial
+	resp, body := post(t, server, "/keys", nil)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.Unmarshal(body, &sender))
+	_, body = post(t, server, "/keys", nil)
+	assert.NoError(t, json.Unmarshal(body, &requester))
+	assert.NotEmpty(t, requester.PrivateKey)
+
+	/* -------------------------------- Encrypt --------------------------------- */
+	resp, body = post(t, server, "/encrypt", map[string]string{
+		"stringToEncrypt":    "Hello, World!",
+		"senderNonce":        sender.Nonce,
+		"requesterNonce":     requester.Nonce,
+		"senderPrivateKey":   sender.PrivateKey,
+		"requesterPublicKey": requester.PublicKey,
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var encrypted encryptionResponse
+	assert.NoError(t, json.Unmarshal(body, &encrypted))
+
+	/* -------------------------------- Decrypt --------------------------------- */
+	decryptRequest := map[string]string{
+		"encryptedData":       encrypted.EncryptedData,
+		"senderNonce":         sender.Nonce,
+		"requesterNonce":      requester.Nonce,
+		"requesterPrivateKey": requester.PrivateKey,
+		"senderPublicKey":     sender.PublicKey,
+	}
+	resp, body = post(t, server, "/decrypt", decryptRequest)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"decryptedData":"Hello, World!"}`, string(body))
+
+	resp, body = post(t, server, "/decrypt?encoding=base64", decryptRequest)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"decryptedDataBase64":"SGVsbG8sIFdvcmxkIQ=="}`, string(body))
+
+	/* ---------------------------- Error Responses ----------------------------- */
+	decryptRequest["senderNonce"] = requester.Nonce
+	resp, body = post(t, server, "/decrypt", decryptRequest)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	assert.Equal(t, "DECRYPTION_FAILED", errorCode(t, body))
+
+	decryptRequest["senderNonce"] = "not base64"
+	resp, body = post(t, server, "/decrypt", decryptRequest)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "INVALID_INPUT", errorCode(t, body))
+}
+
+func TestRequestErrors(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519, WithMaxBodySize(64))
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   string
+	}{
+		{"unknown path", http.MethodGet, "/nope", "", http.StatusNotFound, CodeNotFound},
+		{"wrong method", http.MethodGet, "/encrypt", "", http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+		{"malformed json", http.MethodPost, "/encrypt", "{", http.StatusBadRequest, "INVALID_INPUT"},
+		{"too large", http.MethodPost, "/encrypt", `{"stringToEncrypt":"` + strings.Repeat("a", 100) + `"}`, http.StatusRequestEntityTooLarge, CodePayloadTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.code, errorCode(t, rec.Body.Bytes()))
+		})
+	}
+}
+
+func TestHealthAndReadiness(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	var notReady error = errors.New("key store unreachable")
+	var logs bytes.Buffer
+	handler := Handler(BC25519,
+		WithReadiness(func() error { return notReady }),
+		WithLogger(slog.New(slog.NewTextHandler(&logs, nil))),
+	)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, CodeNotReady, errorCode(t, rec.Body.Bytes()))
+	assert.NotContains(t, rec.Body.String(), "key store unreachable")
+	assert.Contains(t, logs.String(), "key store unreachable")
+
+	notReady = nil
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestDecryptBinary(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	server := httptest.NewServer(Handler(BC25519))
+	defer server.Close()
+	var sender, requester keypairgen.KeyMaterial
+	_, body := post(t, server, "/keys", nil)
+	assert.NoError(t, json.Unmarshal(body, &sender))
+	_, body = post(t, server, "/keys", nil)
+	assert.NoError(t, json.Unmarshal(body, &requester))
+
+	// bytes that aren't valid UTF-8 round-trip through the base64 fields
+	binary := base64.StdEncoding.EncodeToString([]byte{0xff, 0x00, 0xfe, 0x80})
+	_, body = post(t, server, "/encrypt", map[string]string{
+		"stringToEncryptBase64": binary,
+		"senderNonce":           sender.Nonce,
+		"requesterNonce":        requester.Nonce,
+		"senderPrivateKey":      sender.PrivateKey,
+		"requesterPublicKey":    requester.PublicKey,
+	})
+	var encrypted encryptionResponse
+	assert.NoError(t, json.Unmarshal(body, &encrypted))
+
+	resp, body := post(t, server, "/decrypt", map[string]string{
+		"encryptedData":       encrypted.EncryptedData,
+		"senderNonce":         sender.Nonce,
+		"requesterNonce":      requester.Nonce,
+		"requesterPrivateKey": requester.PrivateKey,
+		"senderPublicKey":     sender.PublicKey,
+	})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"decryptedDataBase64":"`+binary+`"}`, string(body))
+}
+
+func TestPanicRecovery(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	handler.mux.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+	handler.mux.HandleFunc("/write-then-panic", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "INTERNAL", errorCode(t, rec.Body.Bytes()))
+
+	// a started response is aborted, not followed by an error body
+	rec = httptest.NewRecorder()
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/write-then-panic", nil))
+	})
+	assert.Equal(t, "partial", rec.Body.String())
+}
@@ -0,0 +1,37 @@
+package httpapi
+
+type encryptionResponse struct {
+	EncryptedData string `json:"encryptedData"`
+}
+
+// decryptionResponse carries the plaintext in exactly one of its fields:
+// DecryptedDataBase64 when it was asked for or the plaintext isn't valid
+// UTF-8, which a JSON string can't hold, DecryptedData otherwise.
+type decryptionResponse struct {
+	DecryptedData       *string `json:"decryptedData,omitempty"`
+	DecryptedDataBase64 *string `json:"decryptedDataBase64,omitempty"`
+}
+
+type statusResponse struct {
+	Status string `json:"status"`
+}
+
+// ErrorResponse is the body of every non-2xx response.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody carries a machine-readable code (see utils.ErrorCode, plus the
+// HTTP-level codes below) and a human-readable message.
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// HTTP-level error codes, in addition to the library's error codes.
+const (
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+	CodeNotReady         = "NOT_READY"
+)
@@ -0,0 +1,7 @@
+// Package bench holds parameters shared by the package benchmarks, so
+// results stay comparable across packages.
+package bench
+
+// PayloadSizes are the plaintext sizes the Encrypt and Decrypt benchmarks
+// run with, from a small JSON field to a large FHIR bundle.
+var PayloadSizes = []int{64, 1 << 10, 64 << 10, 1 << 20}
@@ -0,0 +1,29 @@
+// Package secretwatch lets this module's tests see the buffer of every
+// utils.Secret, to check that shared secrets and derived keys are wiped
+// after use. It is internal so no other module can register a watcher and
+// read key material.
+package secretwatch
+
+import "sync/atomic"
+
+// watcher is notified of every buffer wrapped by utils.NewSecret.
+var watcher atomic.Pointer[func([]byte)]
+
+// Set registers fn to be called with the buffer of every Secret created from
+// now on and returns a function restoring the previous watcher.
+func Set(fn func(buf []byte)) (restore func()) {
+	var previous *func([]byte)
+	if fn == nil {
+		previous = watcher.Swap(nil)
+	} else {
+		previous = watcher.Swap(&fn)
+	}
+	return func() { watcher.Store(previous) }
+}
+
+// Notify passes buf to the registered watcher, if any.
+func Notify(buf []byte) {
+	if fn := watcher.Load(); fn != nil {
+		(*fn)(buf)
+	}
+}
@@ -0,0 +1,202 @@
+// Command genvectors regenerates the self-generated vectors in
+// testdata/vectors.json:
+//
+//	go run ./internal/testvectors/genvectors [-o file]
+//
+// Every self-generated vector gets fresh random keys and nonces that keep the
+// property its name describes (a shared secret with a leading zero byte, a
+// short private key, ...) and its plaintext. External vectors are kept
+// verbatim. The output is produced by this implementation, so it only guards
+// against regressions, not compatibility with other implementations.
+package main
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/zoop/fidelius-go/internal/testvectors"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// keyPair is a generated BC25519 key pair.
+type keyPair struct {
+	privateKey *big.Int
+	x, y       *big.Int
+}
+
+// spec describes the property a self-generated vector must have.
+type spec struct {
+	// accept reports whether the generated keys and shared secret have the
+	// vector's property; nil accepts any.
+	accept func(sender, requester keyPair, sharedSecret []byte) bool
+	x509   bool
+}
+
+var specs = map[string]spec{
+	"basic":           {},
+	"empty-plaintext": {},
+	"utf8-plaintext":  {},
+	"short-shared-secret": {
+		accept: func(_, _ keyPair, sharedSecret []byte) bool {
+			return sharedSecret[0] == 0
+		},
+	},
+	"short-public-key-coordinate": {
+		accept: func(sender, _ keyPair, _ []byte) bool {
+			return len(sender.y.Bytes()) < 32
+		},
+	},
+	"short-private-key": {
+		accept: func(_, requester keyPair, _ []byte) bool {
+			return len(requester.privateKey.Bytes()) < 32
+		},
+	},
+	"x509-public-keys": {x509: true},
+}
+
+func main() {
+	output := flag.String("o", testvectors.File(), "output `file`")
+	flag.Parse()
+	if err := run(*output); err != nil {
+		fmt.Fprintln(os.Stderr, "genvectors:", err)
+		os.Exit(1)
+	}
+}
+
+func run(output string) error {
+	curve, err := utils.GetBC25519Curve()
+	if err != nil {
+		return err
+	}
+	corpus, err := testvectors.LoadCorpus()
+	if err != nil {
+		return err
+	}
+	for i, v := range corpus.Vectors {
+		if v.Source != testvectors.SourceSelfGenerated {
+			continue
+		}
+		s, ok := specs[v.Name]
+		if !ok {
+			return fmt.Errorf("no generator for self-generated vector %q", v.Name)
+		}
+		generated, err := generate(curve, s, v.Plaintext)
+		if err != nil {
+			return fmt.Errorf("%s: %w", v.Name, err)
+		}
+		generated.Name, generated.Comment, generated.Source = v.Name, v.Comment, v.Source
+		corpus.Vectors[i] = generated
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(corpus); err != nil {
+		return err
+	}
+	return os.WriteFile(output, out.Bytes(), 0o644)
+}
+
+// generate draws key pairs until they satisfy s.accept, then derives the
+// shared secret, AES key and ciphertext for plaintext.
+func generate(curve *utils.Curve, s spec, plaintext string) (testvectors.Vector, error) {
+	for {
+		sender, err := newKeyPair(curve)
+		if err != nil {
+			return testvectors.Vector{}, err
+		}
+		requester, err := newKeyPair(curve)
+		if err != nil {
+			return testvectors.Vector{}, err
+		}
+		encoded, err := utils.ComputeSharedSecret(
+			utils.EncodePrivateKeyToBase64(sender.privateKey),
+			utils.EncodePublicKeyToBase64(requester.x, requester.y),
+			curve,
+		)
+		if err != nil {
+			return testvectors.Vector{}, err
+		}
+		sharedSecret, err := utils.DecodeBase64(encoded)
+		if err != nil {
+			return testvectors.Vector{}, err
+		}
+		if s.accept != nil && !s.accept(sender, requester, sharedSecret) {
+			continue
+		}
+		return seal(sender, requester, sharedSecret, s.x509, plaintext)
+	}
+}
+
+func newKeyPair(curve *utils.Curve) (keyPair, error) {
+	privateKey, err := utils.GeneratePrivateKey(curve)
+	if err != nil {
+		return keyPair{}, err
+	}
+	x, y, err := utils.GeneratePublicKey(curve, privateKey)
+	if err != nil {
+		return keyPair{}, err
+	}
+	return keyPair{privateKey: privateKey, x: x, y: y}, nil
+}
+
+// seal encrypts plaintext the way the corpus description specifies, with
+// fresh nonces, independently of the encryption package.
+func seal(sender, requester keyPair, sharedSecret []byte, x509 bool, plaintext string) (testvectors.Vector, error) {
+	senderNonce := utils.GenerateBase64Nonce()
+	requesterNonce := utils.GenerateBase64Nonce()
+	senderNonceBytes, err := utils.DecodeBase64(senderNonce)
+	if err != nil {
+		return testvectors.Vector{}, err
+	}
+	requesterNonceBytes, err := utils.DecodeBase64(requesterNonce)
+	if err != nil {
+		return testvectors.Vector{}, err
+	}
+	xorOfNonces, err := utils.XORBytes(senderNonceBytes, requesterNonceBytes)
+	if err != nil {
+		return testvectors.Vector{}, err
+	}
+	aesKey, err := utils.Sha256HKDF(xorOfNonces[:20], utils.NewSecret(sharedSecret), 32)
+	if err != nil {
+		return testvectors.Vector{}, err
+	}
+	block, err := aes.NewCipher(aesKey.Bytes())
+	if err != nil {
+		return testvectors.Vector{}, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return testvectors.Vector{}, err
+	}
+	ciphertext := gcm.Seal(nil, xorOfNonces[len(xorOfNonces)-12:], []byte(plaintext), nil)
+
+	v := testvectors.Vector{
+		SenderPrivateKey:    utils.EncodePrivateKeyToBase64(sender.privateKey),
+		SenderPublicKey:     utils.EncodePublicKeyToBase64(sender.x, sender.y),
+		SenderNonce:         senderNonce,
+		RequesterPrivateKey: utils.EncodePrivateKeyToBase64(requester.privateKey),
+		RequesterPublicKey:  utils.EncodePublicKeyToBase64(requester.x, requester.y),
+		RequesterNonce:      requesterNonce,
+		SharedSecret:        utils.EncodeBase64(sharedSecret),
+		AESKey:              utils.EncodeBase64(aesKey.Bytes()),
+		Plaintext:           plaintext,
+		EncryptedData:       utils.EncodeBase64(ciphertext),
+	}
+	if x509 {
+		if v.SenderPublicKey, err = utils.EncodeX509PublicKeyToBase64(sender.x, sender.y); err != nil {
+			return testvectors.Vector{}, err
+		}
+		if v.RequesterPublicKey, err = utils.EncodeX509PublicKeyToBase64(requester.x, requester.y); err != nil {
+			return testvectors.Vector{}, err
+		}
+	}
+	return v, nil
+}
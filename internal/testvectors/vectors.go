@@ -0,0 +1,80 @@
+// Package testvectors loads the known-answer vectors in testdata/vectors.json
+// for the package tests.
+package testvectors
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Sources of vectors. External vectors were not produced by this
+// implementation; self-generated ones were (see genvectors) and only guard
+// against regressions.
+const (
+	SourceExternal      = "external"
+	SourceSelfGenerated = "self-generated"
+)
+
+// Vector is one known-answer test case. All keys, nonces and outputs are
+// base64 encoded; Plaintext is the raw string.
+type Vector struct {
+	Name                string `json:"name"`
+	Comment             string `json:"comment"`
+	Source              string `json:"source"`
+	SenderPrivateKey    string `json:"senderPrivateKey,omitempty"`
+	SenderPublicKey     string `json:"senderPublicKey"`
+	SenderNonce         string `json:"senderNonce"`
+	RequesterPrivateKey string `json:"requesterPrivateKey"`
+	RequesterPublicKey  string `json:"requesterPublicKey,omitempty"`
+	RequesterNonce      string `json:"requesterNonce"`
+	SharedSecret        string `json:"sharedSecret"`
+	AESKey              string `json:"aesKey"`
+	Plaintext           string `json:"plaintext"`
+	EncryptedData       string `json:"encryptedData"`
+}
+
+// Corpus is the contents of testdata/vectors.json.
+type Corpus struct {
+	Description string   `json:"description"`
+	Curve       string   `json:"curve"`
+	Vectors     []Vector `json:"vectors"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    Load                                    */
+/* -------------------------------------------------------------------------- */
+// reads testdata/vectors.json from the repository root.
+func Load() ([]Vector, error) {
+	c, err := LoadCorpus()
+	if err != nil {
+		return nil, err
+	}
+	return c.Vectors, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 LoadCorpus                                 */
+/* -------------------------------------------------------------------------- */
+// reads testdata/vectors.json with its description and curve.
+func LoadCorpus() (*Corpus, error) {
+	data, err := os.ReadFile(File())
+	if err != nil {
+		return nil, err
+	}
+	var c Corpus
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    File                                    */
+/* -------------------------------------------------------------------------- */
+// returns the path of testdata/vectors.json.
+func File() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "testdata", "vectors.json")
+}
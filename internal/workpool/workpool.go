@@ -0,0 +1,115 @@
+// Package workpool runs the per-item work of the batch APIs of the
+// encryption and decryption handlers.
+package workpool
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// Run calls fn for every index in [0, n) on up to workers goroutines
+// (runtime.NumCPU() if workers <= 0) and waits for them. Once ctx is done no
+// new items are started; skipped is called for each of them instead. Run
+// returns ctx.Err() if ctx is done by the time every item has finished or
+// been skipped, even if none was skipped: items in flight may have stopped
+// with it too.
+func Run(ctx context.Context, n, workers int, fn func(i int), skipped func(i int)) error {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	next := 0
+feed:
+	for ; next < n; next++ {
+		// Check first so a cancelled context never starts another item
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case indexes <- next:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i := next; i < n; i++ {
+		skipped(i)
+	}
+	return ctx.Err()
+}
+
+// SecretCache computes each shared secret once per batch. It is safe for
+// concurrent use; Destroy wipes every cached secret.
+type SecretCache struct {
+	mu      sync.Mutex
+	entries map[string]*secretEntry
+}
+
+type secretEntry struct {
+	once   sync.Once
+	secret *utils.Secret
+	err    error
+}
+
+// Get returns a copy of the secret cached under key, calling compute the
+// first time the key is seen. Concurrent callers with the same key wait for
+// the first computation. The caller owns the copy and should Destroy it.
+func (c *SecretCache) Get(key string, compute func() (*utils.Secret, error)) (*utils.Secret, error) {
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]*secretEntry)
+	}
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &secretEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.secret, entry.err = compute()
+	})
+	if entry.err != nil {
+		return nil, entry.err
+	}
+	return utils.NewSecret(append([]byte(nil), entry.secret.Bytes()...)), nil
+}
+
+// Len returns the number of distinct keys seen.
+func (c *SecretCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Destroy wipes every cached secret.
+func (c *SecretCache) Destroy() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range c.entries {
+		if entry.secret != nil {
+			entry.secret.Destroy()
+		}
+	}
+	c.entries = nil
+}
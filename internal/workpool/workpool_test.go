@@ -0,0 +1,120 @@
+package workpool
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                Tests for Run                               */
+/* -------------------------------------------------------------------------- */
+func TestRun(t *testing.T) {
+	var running, peak atomic.Int32
+	done := make([]bool, 100)
+	err := Run(context.Background(), len(done), 4, func(i int) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		done[i] = true
+		running.Add(-1)
+	}, func(i int) {
+		t.Errorf("item %d skipped", i)
+	})
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, peak.Load(), int32(4))
+	for i, d := range done {
+		assert.True(t, d, i)
+	}
+
+	assert.NoError(t, Run(context.Background(), 0, 0, func(int) {}, func(int) {}))
+}
+
+func TestRunCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	processed, skipped := map[int]bool{}, map[int]bool{}
+	err := Run(ctx, 100, 2, func(i int) {
+		if i == 10 {
+			cancel()
+		}
+		mu.Lock()
+		processed[i] = true
+		mu.Unlock()
+	}, func(i int) {
+		skipped[i] = true
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 100, len(processed)+len(skipped))
+	assert.NotEmpty(t, skipped)
+	for i := range skipped {
+		assert.False(t, processed[i], i)
+	}
+}
+
+// Cancelling while the last item runs skips nothing, but the item may have
+// stopped with ctx.Err(), so Run must still report it.
+func TestRunCancelLastItem(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var itemErr error
+	err := Run(ctx, 3, 1, func(i int) {
+		if i == 2 {
+			cancel()
+			itemErr = ctx.Err()
+		}
+	}, func(i int) {
+		t.Errorf("item %d skipped", i)
+	})
+	assert.ErrorIs(t, itemErr, context.Canceled)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Tests for SecretCache                            */
+/* -------------------------------------------------------------------------- */
+func TestSecretCache(t *testing.T) {
+	var cache SecretCache
+	var computed atomic.Int32
+	compute := func() (*utils.Secret, error) {
+		computed.Add(1)
+		time.Sleep(5 * time.Millisecond)
+		return utils.NewSecret([]byte{1, 2, 3}), nil
+	}
+
+	var wg sync.WaitGroup
+	secrets := make([]*utils.Secret, 16)
+	for i := range secrets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			secret, err := cache.Get("key", compute)
+			assert.NoError(t, err)
+			secrets[i] = secret
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), computed.Load())
+	assert.Equal(t, 1, cache.Len())
+
+	// every caller gets its own copy
+	secrets[0].Destroy()
+	assert.Equal(t, []byte{1, 2, 3}, secrets[1].Bytes())
+
+	other, err := cache.Get("other", compute)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, other.Bytes())
+	assert.Equal(t, int32(2), computed.Load())
+
+	cache.Destroy()
+	assert.Equal(t, 0, cache.Len())
+}
@@ -1,8 +1,10 @@
 package keypairgen
 
 import (
+	"context"
 	"math/big"
 
+	"github.com/zoop/fidelius-go/observe"
 	"github.com/zoop/fidelius-go/utils"
 )
 
@@ -11,15 +13,40 @@ import (
 /* -------------------------------------------------------------------------- */
 // generates a new KeyMaterial with a random private key.
 func (k *keyPairGenHandler) Generate() (*KeyMaterial, error) {
+	return k.GenerateContext(context.Background())
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               GenerateContext                              */
+/* -------------------------------------------------------------------------- */
+// is Generate that stops with ctx.Err() once ctx is done, checked before
+// and after the scalar multiplication.
+func (k *keyPairGenHandler) GenerateContext(ctx context.Context) (_ *KeyMaterial, err error) {
+	ctx, span := k.span(ctx, "")
+	defer func() { span.End(err) }()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	_, stage := k.span(ctx, observe.StageKeyGen)
 	privateKey, err := utils.GeneratePrivateKey(k.Curve)
 	if err != nil {
+		stage.End(err)
 		return nil, err
 	}
+	defer utils.ZeroizeBigInt(privateKey)
 	publicKeyX, publicKeyY, err := utils.GeneratePublicKey(k.Curve, privateKey)
+	stage.End(err)
 	if err != nil {
 		return nil, err
 	}
-	return k.encodeKeyMaterial(privateKey, publicKeyX, publicKeyY)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	_, stage = k.span(ctx, observe.StageEncode)
+	keyMaterial, err := k.encodeKeyMaterial(privateKey, publicKeyX, publicKeyY)
+	stage.End(err)
+	return keyMaterial, err
 }
 
 /* -------------------------------------------------------------------------- */
@@ -39,8 +66,8 @@ func (k *keyPairGenHandler) GenerateForPrivateKey(privateKey *big.Int) (*KeyMate
 /* -------------------------------------------------------------------------- */
 func (k *keyPairGenHandler) encodeKeyMaterial(privateKey *big.Int, publicKeyX, publicKeyY *big.Int) (*KeyMaterial, error) {
 	privateKeyBase64 := utils.EncodePrivateKeyToBase64(privateKey)
-	publicKeyBase64 := utils.EncodePublicKeyToBase64(publicKeyX, publicKeyY)
-	x509PublicKeyBase64, err := utils.EncodeX509PublicKeyToBase64(publicKeyX, publicKeyY)
+	publicKeyBase64 := utils.EncodeCurvePublicKeyToBase64(k.Curve, publicKeyX, publicKeyY)
+	x509PublicKeyBase64, err := utils.EncodeCurveX509PublicKeyToBase64(k.Curve, publicKeyX, publicKeyY)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,12 @@
 package keypairgen
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/observe"
 	"github.com/zoop/fidelius-go/utils"
 )
 
@@ -26,3 +29,57 @@ func TestGenerateTest(t *testing.T) {
 	// assert.NoError(t, err)
 	// assert.NotEmpty(t, sharedSecret)
 }
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for GenerateContext                         */
+/* -------------------------------------------------------------------------- */
+func TestGenerateContext(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+
+	keyMaterial, err := handler.GenerateContext(context.Background())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, keyMaterial.PrivateKey)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	keyMaterial, err = handler.GenerateContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, keyMaterial)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Tests for WithHook                             */
+/* -------------------------------------------------------------------------- */
+func TestGenerateHook(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	metrics := observe.NewMetrics()
+	tracer := observe.NewMemoryTracer()
+	handler := Handler(BC25519, WithHook(observe.Multi(metrics, observe.Tracing(tracer))))
+
+	_, err = handler.Generate()
+	assert.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = handler.GenerateContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricOperations, "generate", BC25519.Name, "OK"))
+	assert.Equal(t, uint64(1), metrics.Count(observe.MetricOperations, "generate", BC25519.Name, "CANCELED"))
+	var names []string
+	for _, span := range tracer.Spans() {
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, []string{"fidelius.generate.keygen", "fidelius.generate.encode", "fidelius.generate", "fidelius.generate"}, names)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for KeyMaterial JSON                        */
+/* -------------------------------------------------------------------------- */
+func TestKeyMaterialJSON(t *testing.T) {
+	data, err := json.Marshal(KeyMaterial{PrivateKey: "a", PublicKey: "b", X509PublicKey: "c", Nonce: "d"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"privateKey":"a","publicKey":"b","x509PublicKey":"c","nonce":"d"}`, string(data))
+}
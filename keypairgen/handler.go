@@ -1,17 +1,46 @@
 package keypairgen
 
-import "github.com/zoop/fidelius-go/utils"
+import (
+	"context"
+
+	"github.com/zoop/fidelius-go/observe"
+	"github.com/zoop/fidelius-go/utils"
+)
 
 type keyPairGenHandler struct {
 	Curve *utils.Curve
+	Hook  observe.Hook
 }
 
+// Option configures optional behaviour of the key pair generation handler.
+type Option func(*keyPairGenHandler)
+
 /* -------------------------------------------------------------------------- */
 /*                              KeyPairGenHandler                             */
 /* -------------------------------------------------------------------------- */
-func Handler(curve *utils.Curve) *keyPairGenHandler {
+func Handler(curve *utils.Curve, opts ...Option) *keyPairGenHandler {
 	handler := &keyPairGenHandler{
 		Curve: curve,
 	}
+	for _, opt := range opts {
+		opt(handler)
+	}
 	return handler
 }
+
+/* -------------------------------------------------------------------------- */
+/*                                  WithHook                                  */
+/* -------------------------------------------------------------------------- */
+// reports every Generate, and each of its stages, to hook (see package
+// observe).
+func WithHook(hook observe.Hook) Option {
+	return func(k *keyPairGenHandler) {
+		k.Hook = hook
+	}
+}
+
+// span starts an observe span for stage of Generate, or for the whole
+// operation if stage is empty.
+func (k *keyPairGenHandler) span(ctx context.Context, stage observe.Stage) (context.Context, *observe.Span) {
+	return observe.Start(ctx, k.Hook, observe.Event{Operation: observe.Generate, Stage: stage, Curve: k.Curve.Name})
+}
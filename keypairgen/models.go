@@ -1,8 +1,8 @@
 package keypairgen
 
 type KeyMaterial struct {
-	PrivateKey    string
-	PublicKey     string
-	X509PublicKey string
-	Nonce         string
+	PrivateKey    string `json:"privateKey"`
+	PublicKey     string `json:"publicKey"`
+	X509PublicKey string `json:"x509PublicKey"`
+	Nonce         string `json:"nonce"`
 }
@@ -0,0 +1,184 @@
+package keystore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const fileStoreExt = ".json"
+
+type fileStore struct {
+	mu  sync.Mutex
+	Dir string
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                NewFileStore                                */
+/* -------------------------------------------------------------------------- */
+// creates a KeyStore that keeps one JSON file per entry in dir. The directory
+// is created if needed and files are written with owner-only permissions.
+func NewFileStore(dir string) (*fileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "[NewFileStore][os.MkdirAll]")
+	}
+	return &fileStore{Dir: dir}, nil
+}
+
+// path maps a key ID onto a file name that is safe for any key ID. The ID is
+// hashed, so long IDs don't exceed the file system's name limit.
+func (f *fileStore) path(keyID string) string {
+	sum := sha256.Sum256([]byte(keyID))
+	return filepath.Join(f.Dir, hex.EncodeToString(sum[:])+fileStoreExt)
+}
+
+func (f *fileStore) Put(entry *Entry) error {
+	if err := validateEntry(entry); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.Wrap(err, "[Put][json.Marshal]")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if entry.TransactionID != "" {
+		stored, err := f.readAll()
+		if err != nil {
+			return err
+		}
+		err = checkTransaction(entry, stored, func(other *Entry) error {
+			return errors.Wrap(os.Remove(f.path(other.KeyID)), "[Put][os.Remove]")
+		})
+		if err != nil {
+			return err
+		}
+	}
+	tmp, err := os.CreateTemp(f.Dir, ".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "[Put][os.CreateTemp]")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "[Put][tmp.Write]")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "[Put][tmp.Close]")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), f.path(entry.KeyID)), "[Put][os.Rename]")
+}
+
+func (f *fileStore) Get(keyID string) (*Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, err := f.read(f.path(keyID))
+	if err != nil {
+		return nil, err
+	}
+	return f.use(entry)
+}
+
+func (f *fileStore) GetByTransaction(transactionID string) (*Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries, err := f.readAll()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if transactionID != "" && entry.TransactionID == transactionID {
+			return f.use(entry)
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// use applies the expiry rule to an entry that was found.
+// The caller must hold f.mu.
+func (f *fileStore) use(entry *Entry) (*Entry, error) {
+	if entry.Expired(time.Now()) {
+		os.Remove(f.path(entry.KeyID))
+		return nil, ErrExpired
+	}
+	return entry, nil
+}
+
+func (f *fileStore) Delete(keyID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	err := os.Remove(f.path(keyID))
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (f *fileStore) List() ([]*Entry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.readAll()
+}
+
+func (f *fileStore) Purge() (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entries, err := f.readAll()
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	purged := 0
+	for _, entry := range entries {
+		if entry.Expired(now) {
+			if err := os.Remove(f.path(entry.KeyID)); err != nil {
+				return purged, errors.Wrap(err, "[Purge][os.Remove]")
+			}
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (f *fileStore) read(path string) (*Entry, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "[read][os.ReadFile]")
+	}
+	entry := &Entry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, errors.Wrap(err, "[read][json.Unmarshal]")
+	}
+	return entry, nil
+}
+
+func (f *fileStore) readAll() ([]*Entry, error) {
+	dirEntries, err := os.ReadDir(f.Dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "[readAll][os.ReadDir]")
+	}
+	var entries []*Entry
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasSuffix(name, fileStoreExt) || strings.HasPrefix(name, ".") {
+			continue
+		}
+		entry, err := f.read(filepath.Join(f.Dir, name))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,91 @@
+package keystore
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound             = errors.New("key not found")
+	ErrExpired              = errors.New("key expired")
+	ErrInvalidEntry         = errors.New("invalid key store entry")
+	ErrDuplicateTransaction = errors.New("transaction already has a key")
+)
+
+// KeyStore persists HIU key pairs between the data-flow request and the
+// arrival of the encrypted bundle.
+//
+// A transaction ID maps to at most one entry: Put returns
+// ErrDuplicateTransaction when an unexpired entry with another key ID already
+// uses it, and drops expired ones, so GetByTransaction is unambiguous.
+//
+// Get and GetByTransaction return ErrExpired for entries past their expiry
+// (removing them). They never consume SingleUse entries: whoever uses the
+// key deletes the entry once the use succeeded, as DecryptByKeyID does after
+// a successful decryption, so a failed attempt doesn't burn the key.
+type KeyStore interface {
+	Put(entry *Entry) error
+	Get(keyID string) (*Entry, error)
+	GetByTransaction(transactionID string) (*Entry, error)
+	Delete(keyID string) error
+	List() ([]*Entry, error)
+	Purge() (int, error)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                StartPurger                                 */
+/* -------------------------------------------------------------------------- */
+// periodically removes expired entries from the store until stop is called.
+// Purge errors are passed to onError, which may be nil to ignore them; the
+// purger keeps running either way.
+func StartPurger(store KeyStore, interval time.Duration, onError func(error)) (stop func()) {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := store.Purge(); err != nil && onError != nil {
+					onError(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() { close(done) })
+	}
+}
+
+// checkTransaction enforces the one-entry-per-transaction rule for entry
+// against the stored entries, calling remove for expired entries that
+// shared its transaction ID.
+func checkTransaction(entry *Entry, stored []*Entry, remove func(*Entry) error) error {
+	if entry.TransactionID == "" {
+		return nil
+	}
+	now := time.Now()
+	for _, other := range stored {
+		if other.TransactionID != entry.TransactionID || other.KeyID == entry.KeyID {
+			continue
+		}
+		if !other.Expired(now) {
+			return ErrDuplicateTransaction
+		}
+		if err := remove(other); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateEntry(entry *Entry) error {
+	if entry == nil || entry.KeyID == "" || entry.PrivateKey == "" || entry.Nonce == "" {
+		return ErrInvalidEntry
+	}
+	return nil
+}
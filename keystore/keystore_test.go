@@ -0,0 +1,179 @@
+package keystore
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+func newTestEntry(t *testing.T, keyID string, ttl time.Duration) *Entry {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	return NewEntry(keyID, keyMaterial, ttl)
+}
+
+func testStores(t *testing.T) map[string]KeyStore {
+	fileStore, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	return map[string]KeyStore{
+		"memory": NewMemoryStore(),
+		"file":   fileStore,
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Tests for KeyStore                            */
+/* -------------------------------------------------------------------------- */
+func TestKeyStore(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			entry := newTestEntry(t, "txn-1/key", time.Hour)
+			entry.TransactionID = "txn-1"
+			assert.NoError(t, store.Put(entry))
+
+			got, err := store.Get("txn-1/key")
+			assert.NoError(t, err)
+			assert.Equal(t, entry.PrivateKey, got.PrivateKey)
+			assert.Equal(t, entry.Nonce, got.Nonce)
+
+			got, err = store.GetByTransaction("txn-1")
+			assert.NoError(t, err)
+			assert.Equal(t, entry.KeyID, got.KeyID)
+
+			entries, err := store.List()
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1)
+
+			assert.NoError(t, store.Delete("txn-1/key"))
+			_, err = store.Get("txn-1/key")
+			assert.ErrorIs(t, err, ErrNotFound)
+			assert.ErrorIs(t, store.Delete("txn-1/key"), ErrNotFound)
+			assert.ErrorIs(t, store.Put(&Entry{}), ErrInvalidEntry)
+		})
+	}
+}
+
+func TestKeyStoreExpiryAndSingleUse(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			expired := newTestEntry(t, "expired", time.Hour)
+			expired.ExpiresAt = time.Now().Add(-time.Minute)
+			assert.NoError(t, store.Put(expired))
+			_, err := store.Get("expired")
+			assert.ErrorIs(t, err, ErrExpired)
+			_, err = store.Get("expired")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			once := newTestEntry(t, "once", 0)
+			once.SingleUse = true
+			assert.NoError(t, store.Put(once))
+			// reading a single-use entry doesn't consume it; deleting does
+			_, err = store.Get("once")
+			assert.NoError(t, err)
+			got, err := store.Get("once")
+			assert.NoError(t, err)
+			assert.True(t, got.SingleUse)
+			assert.NoError(t, store.Delete("once"))
+			_, err = store.Get("once")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			stale := newTestEntry(t, "stale", time.Hour)
+			stale.ExpiresAt = time.Now().Add(-time.Minute)
+			assert.NoError(t, store.Put(stale))
+			assert.NoError(t, store.Put(newTestEntry(t, "fresh", time.Hour)))
+			purged, err := store.Purge()
+			assert.NoError(t, err)
+			assert.Equal(t, 1, purged)
+			_, err = store.Get("fresh")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestKeyStoreDuplicateTransaction(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			first := newTestEntry(t, "first", time.Hour)
+			first.TransactionID = "txn-1"
+			assert.NoError(t, store.Put(first))
+			// the same key ID may be stored again
+			assert.NoError(t, store.Put(first))
+
+			second := newTestEntry(t, "second", time.Hour)
+			second.TransactionID = "txn-1"
+			assert.ErrorIs(t, store.Put(second), ErrDuplicateTransaction)
+			got, err := store.GetByTransaction("txn-1")
+			assert.NoError(t, err)
+			assert.Equal(t, "first", got.KeyID)
+
+			// an expired entry gives way to the new one
+			first.ExpiresAt = time.Now().Add(-time.Minute)
+			assert.NoError(t, store.Put(first))
+			assert.NoError(t, store.Put(second))
+			got, err = store.GetByTransaction("txn-1")
+			assert.NoError(t, err)
+			assert.Equal(t, "second", got.KeyID)
+			_, err = store.Get("first")
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestFileStoreLongKeyID(t *testing.T) {
+	store, err := NewFileStore(t.TempDir())
+	assert.NoError(t, err)
+	keyID := strings.Repeat("k", 1024)
+	assert.NoError(t, store.Put(newTestEntry(t, keyID, 0)))
+	got, err := store.Get(keyID)
+	assert.NoError(t, err)
+	assert.Equal(t, keyID, got.KeyID)
+	assert.True(t, got.ExpiresAt.IsZero())
+	assert.NoError(t, store.Delete(keyID))
+}
+
+func TestStartPurger(t *testing.T) {
+	store := NewMemoryStore()
+	entry := newTestEntry(t, "short-lived", time.Millisecond)
+	assert.NoError(t, store.Put(entry))
+
+	stop := StartPurger(store, 5*time.Millisecond, nil)
+	defer stop()
+	assert.Eventually(t, func() bool {
+		entries, _ := store.List()
+		return len(entries) == 0
+	}, time.Second, 5*time.Millisecond)
+	stop()
+}
+
+// failingStore is a KeyStore whose Purge always fails.
+type failingStore struct {
+	KeyStore
+}
+
+func (failingStore) Purge() (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestStartPurgerErrors(t *testing.T) {
+	errs := make(chan error, 1)
+	stop := StartPurger(failingStore{NewMemoryStore()}, 5*time.Millisecond, func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+	defer stop()
+	select {
+	case err := <-errs:
+		assert.EqualError(t, err, "disk full")
+	case <-time.After(time.Second):
+		t.Fatal("purge error not reported")
+	}
+}
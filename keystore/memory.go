@@ -0,0 +1,107 @@
+package keystore
+
+import (
+	"sync"
+	"time"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*Entry
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               NewMemoryStore                               */
+/* -------------------------------------------------------------------------- */
+// creates a KeyStore that keeps entries in process memory.
+func NewMemoryStore() *memoryStore {
+	return &memoryStore{
+		entries: map[string]*Entry{},
+	}
+}
+
+func (m *memoryStore) Put(entry *Entry) error {
+	if err := validateEntry(entry); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := make([]*Entry, 0, len(m.entries))
+	for _, other := range m.entries {
+		stored = append(stored, other)
+	}
+	err := checkTransaction(entry, stored, func(other *Entry) error {
+		delete(m.entries, other.KeyID)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	m.entries[entry.KeyID] = entry.clone()
+	return nil
+}
+
+func (m *memoryStore) Get(keyID string) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[keyID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return m.use(entry)
+}
+
+func (m *memoryStore) GetByTransaction(transactionID string) (*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, entry := range m.entries {
+		if transactionID != "" && entry.TransactionID == transactionID {
+			return m.use(entry)
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// use applies the expiry rule to an entry that was found.
+// The caller must hold m.mu.
+func (m *memoryStore) use(entry *Entry) (*Entry, error) {
+	if entry.Expired(time.Now()) {
+		delete(m.entries, entry.KeyID)
+		return nil, ErrExpired
+	}
+	return entry.clone(), nil
+}
+
+func (m *memoryStore) Delete(keyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.entries[keyID]; !ok {
+		return ErrNotFound
+	}
+	delete(m.entries, keyID)
+	return nil
+}
+
+func (m *memoryStore) List() ([]*Entry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries := make([]*Entry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, entry.clone())
+	}
+	return entries, nil
+}
+
+func (m *memoryStore) Purge() (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	purged := 0
+	for keyID, entry := range m.entries {
+		if entry.Expired(now) {
+			delete(m.entries, keyID)
+			purged++
+		}
+	}
+	return purged, nil
+}
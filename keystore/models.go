@@ -0,0 +1,56 @@
+package keystore
+
+import (
+	"time"
+
+	"github.com/zoop/fidelius-go/keypairgen"
+)
+
+// Entry is a stored HIU key pair together with the nonce that was sent
+// alongside its public key in the data-flow request.
+type Entry struct {
+	KeyID         string    `json:"keyId"`
+	TransactionID string    `json:"transactionId,omitempty"`
+	PrivateKey    string    `json:"privateKey"`
+	PublicKey     string    `json:"publicKey"`
+	X509PublicKey string    `json:"x509PublicKey,omitempty"`
+	Nonce         string    `json:"nonce"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+	SingleUse     bool      `json:"singleUse,omitempty"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  NewEntry                                  */
+/* -------------------------------------------------------------------------- */
+// builds an Entry from freshly generated key material. A zero ttl means the
+// entry never expires.
+func NewEntry(keyID string, keyMaterial *keypairgen.KeyMaterial, ttl time.Duration) *Entry {
+	now := time.Now()
+	entry := &Entry{
+		KeyID:         keyID,
+		PrivateKey:    keyMaterial.PrivateKey,
+		PublicKey:     keyMaterial.PublicKey,
+		X509PublicKey: keyMaterial.X509PublicKey,
+		Nonce:         keyMaterial.Nonce,
+		CreatedAt:     now,
+	}
+	if ttl > 0 {
+		entry.ExpiresAt = now.Add(ttl)
+	}
+	return entry
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Expired                                  */
+/* -------------------------------------------------------------------------- */
+// reports whether the entry has passed its expiry time.
+func (e *Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// clone returns a copy so callers can't mutate stored entries.
+func (e *Entry) clone() *Entry {
+	c := *e
+	return &c
+}
@@ -0,0 +1,337 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/utils"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	sealedPrefix     = "sealed:v2:"
+	legacyPrefix     = "sealed:v1:"
+	masterKeyLength  = 32
+	minPassphraseLen = 12
+	minSaltLength    = 16
+)
+
+var (
+	ErrUnknownMasterKey = errors.New("entry sealed under an unknown master key")
+	ErrInvalidMasterKey = errors.New("invalid master key")
+	ErrUnsealedEntry    = errors.New("entry is not sealed or uses a legacy seal")
+)
+
+// MasterKey is a key-encryption key used to seal private keys and nonces
+// before they are handed to the underlying KeyStore.
+type MasterKey struct {
+	ID  string
+	key []byte
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                NewMasterKey                                */
+/* -------------------------------------------------------------------------- */
+// wraps a raw 32 byte key, e.g. one fetched from a secrets manager.
+func NewMasterKey(id string, key []byte) (*MasterKey, error) {
+	if id == "" || strings.Contains(id, ":") || len(key) != masterKeyLength {
+		return nil, ErrInvalidMasterKey
+	}
+	return &MasterKey{ID: id, key: append([]byte(nil), key...)}, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               DeriveMasterKey                              */
+/* -------------------------------------------------------------------------- */
+// derives a master key from a passphrase with scrypt. The salt must be
+// random, at least 16 bytes and stored alongside the key store.
+func DeriveMasterKey(id, passphrase string, salt []byte) (*MasterKey, error) {
+	if len(passphrase) < minPassphraseLen {
+		return nil, errors.Wrap(ErrInvalidMasterKey, "passphrase too short")
+	}
+	if len(salt) < minSaltLength {
+		return nil, errors.Wrap(ErrInvalidMasterKey, "salt too short")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, masterKeyLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "[DeriveMasterKey][scrypt.Key]")
+	}
+	return NewMasterKey(id, key)
+}
+
+// seal encrypts value with AES-256-GCM, binding it to the entry and field
+// it belongs to so sealed values can't be swapped between entries.
+func (m *MasterKey) seal(value string, aad []byte) (string, error) {
+	aesGCM, err := m.aead()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, aesGCM.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", errors.Wrap(err, "[seal][rand.Read]")
+	}
+	sealed := aesGCM.Seal(nonce, nonce, []byte(value), aad)
+	return sealedPrefix + m.ID + ":" + utils.EncodeBase64(sealed), nil
+}
+
+func (m *MasterKey) open(sealed []byte, aad []byte) (string, error) {
+	aesGCM, err := m.aead()
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < aesGCM.NonceSize()+aesGCM.Overhead() {
+		return "", errors.New("sealed value too short")
+	}
+	nonce, ciphertext := sealed[:aesGCM.NonceSize()], sealed[aesGCM.NonceSize():]
+	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, aad)
+	if err != nil {
+		return "", errors.Wrap(err, "[open][aesGCM.Open]")
+	}
+	return string(plaintext), nil
+}
+
+func (m *MasterKey) aead() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(m.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+type sealedStore struct {
+	// AllowUnsealed accepts entries written before sealing was enabled, and
+	// entries sealed with the v1 format, which left their metadata
+	// unauthenticated, and returns them as stored. Turn it on only to
+	// migrate an existing store with Rotate: anyone who can write to the
+	// underlying store could otherwise plant a key of their choosing.
+	AllowUnsealed bool
+
+	mu      sync.RWMutex
+	inner   KeyStore
+	current *MasterKey
+	keys    map[string]*MasterKey
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               NewSealedStore                               */
+/* -------------------------------------------------------------------------- */
+// wraps a KeyStore so private keys and nonces are sealed under current before
+// they are stored. The sealed values also authenticate the entry's key ID,
+// transaction ID, public keys, expiry and single-use flag, so none of them
+// can be changed in the underlying store unnoticed. Previous master keys are
+// only used to open old entries. Entries that aren't sealed are rejected
+// with ErrUnsealedEntry unless AllowUnsealed is set.
+func NewSealedStore(inner KeyStore, current *MasterKey, previous ...*MasterKey) (*sealedStore, error) {
+	if inner == nil {
+		return nil, errors.New("inner store cannot be nil")
+	}
+	if current == nil {
+		return nil, errors.Wrap(ErrInvalidMasterKey, "current master key is nil")
+	}
+	s := &sealedStore{
+		inner:   inner,
+		current: current,
+		keys:    map[string]*MasterKey{current.ID: current},
+	}
+	for _, key := range previous {
+		if key == nil {
+			return nil, errors.Wrap(ErrInvalidMasterKey, "previous master key is nil")
+		}
+		s.keys[key.ID] = key
+	}
+	return s, nil
+}
+
+func (s *sealedStore) Put(entry *Entry) error {
+	if err := validateEntry(entry); err != nil {
+		return err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sealed, err := s.sealEntry(entry, s.current)
+	if err != nil {
+		return err
+	}
+	return s.inner.Put(sealed)
+}
+
+// Reads and writes hold s.mu for reading while they use the inner store, so
+// Rotate, which holds it for writing, never sees them half done.
+
+func (s *sealedStore) Get(keyID string) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, err := s.inner.Get(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.openEntry(entry)
+}
+
+func (s *sealedStore) GetByTransaction(transactionID string) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, err := s.inner.GetByTransaction(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.openEntry(entry)
+}
+
+func (s *sealedStore) Delete(keyID string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inner.Delete(keyID)
+}
+
+func (s *sealedStore) List() ([]*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entries, err := s.inner.List()
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		if entries[i], err = s.openEntry(entry); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+func (s *sealedStore) Purge() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inner.Purge()
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Rotate                                   */
+/* -------------------------------------------------------------------------- */
+// re-seals every stored entry under next and makes it the current master key.
+// It returns the number of entries re-wrapped. Entries deleted or expired
+// while Rotate runs, e.g. through the inner store directly, are skipped
+// rather than written back.
+func (s *sealedStore) Rotate(next *MasterKey) (int, error) {
+	if next == nil {
+		return 0, errors.Wrap(ErrInvalidMasterKey, "next master key is nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[next.ID] = next
+
+	entries, err := s.inner.List()
+	if err != nil {
+		return 0, err
+	}
+	rewrapped := 0
+	for _, entry := range entries {
+		opened, err := s.openEntry(entry)
+		if err != nil {
+			return rewrapped, errors.Wrapf(err, "[Rotate] entry %q", entry.KeyID)
+		}
+		sealed, err := s.sealEntry(opened, next)
+		if err != nil {
+			return rewrapped, err
+		}
+		if _, err := s.inner.Get(entry.KeyID); errors.Is(err, ErrNotFound) || errors.Is(err, ErrExpired) {
+			continue
+		} else if err != nil {
+			return rewrapped, err
+		}
+		if err := s.inner.Put(sealed); err != nil {
+			return rewrapped, err
+		}
+		rewrapped++
+	}
+
+	s.current = next
+	s.keys = map[string]*MasterKey{next.ID: next}
+	return rewrapped, nil
+}
+
+func (s *sealedStore) sealEntry(entry *Entry, key *MasterKey) (*Entry, error) {
+	sealed := entry.clone()
+	var err error
+	if sealed.PrivateKey, err = key.seal(entry.PrivateKey, entryAAD(entry, "privateKey")); err != nil {
+		return nil, err
+	}
+	if sealed.Nonce, err = key.seal(entry.Nonce, entryAAD(entry, "nonce")); err != nil {
+		return nil, err
+	}
+	return sealed, nil
+}
+
+func (s *sealedStore) openEntry(entry *Entry) (*Entry, error) {
+	opened := entry.clone()
+	var err error
+	if opened.PrivateKey, err = s.openField(entry.PrivateKey, entry, "privateKey"); err != nil {
+		return nil, err
+	}
+	if opened.Nonce, err = s.openField(entry.Nonce, entry, "nonce"); err != nil {
+		return nil, err
+	}
+	return opened, nil
+}
+
+func (s *sealedStore) openField(value string, entry *Entry, field string) (string, error) {
+	prefix, aad := sealedPrefix, entryAAD(entry, field)
+	switch {
+	case strings.HasPrefix(value, sealedPrefix):
+	case !s.AllowUnsealed:
+		return "", ErrUnsealedEntry
+	case strings.HasPrefix(value, legacyPrefix):
+		prefix, aad = legacyPrefix, legacyFieldAAD(entry.KeyID, field)
+	default:
+		return value, nil
+	}
+	keyID, encoded, ok := strings.Cut(strings.TrimPrefix(value, prefix), ":")
+	if !ok {
+		return "", errors.New("malformed sealed value")
+	}
+	key, ok := s.keys[keyID]
+	if !ok {
+		return "", errors.Wrap(ErrUnknownMasterKey, keyID)
+	}
+	sealed, err := utils.DecodeBase64(encoded)
+	if err != nil {
+		return "", errors.Wrap(err, "[openField][utils.DecodeBase64]")
+	}
+	return key.open(sealed, aad)
+}
+
+// entryAAD binds a sealed field to its entry: the field name and every
+// clear-text field that decides how the key may be used, each prefixed with
+// its 4-byte length.
+func entryAAD(entry *Entry, field string) []byte {
+	var expiresAt string
+	if !entry.ExpiresAt.IsZero() {
+		expiresAt = strconv.FormatInt(entry.ExpiresAt.UnixNano(), 10)
+	}
+	aad := []byte("fidelius-go/keystore/v2")
+	for _, value := range []string{
+		field,
+		entry.KeyID,
+		entry.TransactionID,
+		entry.PublicKey,
+		entry.X509PublicKey,
+		expiresAt,
+		strconv.FormatBool(entry.SingleUse),
+	} {
+		aad = binary.BigEndian.AppendUint32(aad, uint32(len(value)))
+		aad = append(aad, value...)
+	}
+	return aad
+}
+
+// legacyFieldAAD is the additional data of v1 sealed values, which only
+// bound the key ID.
+func legacyFieldAAD(keyID, field string) []byte {
+	return []byte("fidelius-go/keystore|" + keyID + "|" + field)
+}
@@ -0,0 +1,246 @@
+package keystore
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestMasterKey(t *testing.T, id string, fill byte) *MasterKey {
+	key, err := NewMasterKey(id, bytes.Repeat([]byte{fill}, 32))
+	assert.NoError(t, err)
+	return key
+}
+
+func newTestSealedStore(t *testing.T, inner KeyStore, current *MasterKey, previous ...*MasterKey) *sealedStore {
+	store, err := NewSealedStore(inner, current, previous...)
+	assert.NoError(t, err)
+	return store
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Tests for SealedStore                           */
+/* -------------------------------------------------------------------------- */
+func TestSealedStore(t *testing.T) {
+	inner := NewMemoryStore()
+	store := newTestSealedStore(t, inner, newTestMasterKey(t, "kek-1", 0x01))
+
+	entry := newTestEntry(t, "txn-1", time.Hour)
+	assert.NoError(t, store.Put(entry))
+
+	// nothing secret reaches the underlying store in the clear
+	raw, err := inner.Get("txn-1")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(raw.PrivateKey, "sealed:v2:kek-1:"))
+	assert.True(t, strings.HasPrefix(raw.Nonce, "sealed:v2:kek-1:"))
+	assert.Equal(t, entry.PublicKey, raw.PublicKey)
+
+	got, err := store.Get("txn-1")
+	assert.NoError(t, err)
+	assert.Equal(t, entry.PrivateKey, got.PrivateKey)
+	assert.Equal(t, entry.Nonce, got.Nonce)
+
+	// a sealed value moved to another entry no longer opens
+	other := newTestEntry(t, "txn-2", time.Hour)
+	assert.NoError(t, store.Put(other))
+	raw.KeyID = "txn-2"
+	assert.NoError(t, inner.Put(raw))
+	_, err = store.Get("txn-2")
+	assert.Error(t, err)
+
+	// a different master key can't open the entry
+	_, err = newTestSealedStore(t, inner, newTestMasterKey(t, "kek-1", 0x02)).Get("txn-1")
+	assert.Error(t, err)
+	_, err = newTestSealedStore(t, inner, newTestMasterKey(t, "kek-9", 0x01)).Get("txn-1")
+	assert.ErrorIs(t, err, ErrUnknownMasterKey)
+}
+
+func TestNewSealedStore(t *testing.T) {
+	key := newTestMasterKey(t, "kek-1", 0x01)
+	_, err := NewSealedStore(NewMemoryStore(), nil)
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+	_, err = NewSealedStore(NewMemoryStore(), key, nil)
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+	_, err = NewSealedStore(nil, key)
+	assert.Error(t, err)
+
+	store := newTestSealedStore(t, NewMemoryStore(), key)
+	_, err = store.Rotate(nil)
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+}
+
+// Every clear-text field that decides how a key may be used is bound to the
+// sealed values, so changing it in the underlying store breaks the entry.
+func TestSealedStoreTampering(t *testing.T) {
+	other := newTestEntry(t, "other", time.Hour)
+	tampers := map[string]func(e *Entry){
+		"keyId":         func(e *Entry) { e.KeyID = "other" },
+		"transactionId": func(e *Entry) { e.TransactionID = "txn-9" },
+		"publicKey":     func(e *Entry) { e.PublicKey = other.PublicKey },
+		"x509PublicKey": func(e *Entry) { e.X509PublicKey = other.X509PublicKey },
+		"expiresAt":     func(e *Entry) { e.ExpiresAt = e.ExpiresAt.Add(24 * time.Hour) },
+		"noExpiry":      func(e *Entry) { e.ExpiresAt = time.Time{} },
+		"singleUse":     func(e *Entry) { e.SingleUse = false },
+	}
+	for name, tamper := range tampers {
+		t.Run(name, func(t *testing.T) {
+			inner := NewMemoryStore()
+			store := newTestSealedStore(t, inner, newTestMasterKey(t, "kek-1", 0x01))
+			entry := newTestEntry(t, "txn-1", time.Hour)
+			entry.TransactionID = "txn-1"
+			entry.SingleUse = true
+			assert.NoError(t, store.Put(entry))
+
+			raw, err := inner.Get("txn-1")
+			assert.NoError(t, err)
+			tamper(raw)
+			assert.NoError(t, inner.Delete("txn-1"))
+			assert.NoError(t, inner.Put(raw))
+			_, err = store.Get(raw.KeyID)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSealedStoreRotate(t *testing.T) {
+	inner := NewMemoryStore()
+	oldKey := newTestMasterKey(t, "kek-1", 0x01)
+	newKey := newTestMasterKey(t, "kek-2", 0x02)
+
+	// an entry written before sealing was enabled is sealed by the rotation
+	legacy := newTestEntry(t, "legacy", time.Hour)
+	assert.NoError(t, inner.Put(legacy))
+
+	store := newTestSealedStore(t, inner, oldKey)
+	_, err := store.Get("legacy")
+	assert.ErrorIs(t, err, ErrUnsealedEntry)
+	_, err = store.Rotate(newKey)
+	assert.ErrorIs(t, err, ErrUnsealedEntry)
+
+	store = newTestSealedStore(t, inner, oldKey)
+	store.AllowUnsealed = true
+	entry := newTestEntry(t, "txn-1", time.Hour)
+	assert.NoError(t, store.Put(entry))
+
+	rewrapped, err := store.Rotate(newKey)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, rewrapped)
+
+	entries, err := inner.List()
+	assert.NoError(t, err)
+	for _, raw := range entries {
+		assert.True(t, strings.HasPrefix(raw.PrivateKey, "sealed:v2:kek-2:"))
+	}
+
+	got, err := newTestSealedStore(t, inner, newKey).Get("legacy")
+	assert.NoError(t, err)
+	assert.Equal(t, legacy.PrivateKey, got.PrivateKey)
+	_, err = newTestSealedStore(t, inner, oldKey).Get("txn-1")
+	assert.ErrorIs(t, err, ErrUnknownMasterKey)
+}
+
+func TestSealedStoreLegacySeal(t *testing.T) {
+	inner := NewMemoryStore()
+	key := newTestMasterKey(t, "kek-1", 0x01)
+	entry := newTestEntry(t, "txn-1", time.Hour)
+
+	// an entry sealed in the v1 format, which only bound the key ID
+	legacy := entry.clone()
+	privateKey, err := key.seal(entry.PrivateKey, legacyFieldAAD(entry.KeyID, "privateKey"))
+	assert.NoError(t, err)
+	nonce, err := key.seal(entry.Nonce, legacyFieldAAD(entry.KeyID, "nonce"))
+	assert.NoError(t, err)
+	legacy.PrivateKey = strings.Replace(privateKey, sealedPrefix, legacyPrefix, 1)
+	legacy.Nonce = strings.Replace(nonce, sealedPrefix, legacyPrefix, 1)
+	assert.NoError(t, inner.Put(legacy))
+
+	store := newTestSealedStore(t, inner, key)
+	_, err = store.Get("txn-1")
+	assert.ErrorIs(t, err, ErrUnsealedEntry)
+
+	// it is only read to migrate it, which re-seals it in the current format
+	store.AllowUnsealed = true
+	_, err = store.Rotate(newTestMasterKey(t, "kek-2", 0x02))
+	assert.NoError(t, err)
+	store.AllowUnsealed = false
+	got, err := store.Get("txn-1")
+	assert.NoError(t, err)
+	assert.Equal(t, entry.PrivateKey, got.PrivateKey)
+	assert.Equal(t, entry.Nonce, got.Nonce)
+}
+
+func TestDeriveMasterKey(t *testing.T) {
+	salt := bytes.Repeat([]byte{0x5a}, 16)
+	first, err := DeriveMasterKey("kek-1", "correct horse battery staple", salt)
+	assert.NoError(t, err)
+	second, err := DeriveMasterKey("kek-1", "correct horse battery staple", salt)
+	assert.NoError(t, err)
+	assert.Equal(t, first.key, second.key)
+
+	_, err = DeriveMasterKey("kek-1", "short", salt)
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+	_, err = DeriveMasterKey("kek-1", "correct horse battery staple", salt[:8])
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+	_, err = NewMasterKey("kek:1", salt)
+	assert.ErrorIs(t, err, ErrInvalidMasterKey)
+}
+
+// racingStore deletes an entry the first time Rotate lists the store, as if
+// a concurrent writer removed it while Rotate ran.
+type racingStore struct {
+	KeyStore
+	deleteOnList string
+}
+
+func (r *racingStore) List() ([]*Entry, error) {
+	entries, err := r.KeyStore.List()
+	if r.deleteOnList != "" {
+		r.KeyStore.Delete(r.deleteOnList)
+		r.deleteOnList = ""
+	}
+	return entries, err
+}
+
+func TestSealedStoreRotateSkipsDeleted(t *testing.T) {
+	inner := &racingStore{KeyStore: NewMemoryStore()}
+	store := newTestSealedStore(t, inner, newTestMasterKey(t, "kek-1", 0x01))
+	assert.NoError(t, store.Put(newTestEntry(t, "used", time.Hour)))
+	assert.NoError(t, store.Put(newTestEntry(t, "kept", time.Hour)))
+
+	inner.deleteOnList = "used"
+	rewrapped, err := store.Rotate(newTestMasterKey(t, "kek-2", 0x02))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rewrapped)
+	_, err = store.Get("used")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Get("kept")
+	assert.NoError(t, err)
+}
+
+func TestSealedStoreConcurrentRotate(t *testing.T) {
+	store := newTestSealedStore(t, NewMemoryStore(), newTestMasterKey(t, "kek-0", 0x00))
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, store.Put(newTestEntry(t, fmt.Sprint("txn-", i), time.Hour)))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 1; i <= 5; i++ {
+			_, err := store.Rotate(newTestMasterKey(t, fmt.Sprint("kek-", i), byte(i)))
+			assert.NoError(t, err)
+		}
+	}()
+	// readers never see an entry sealed under a key Rotate already dropped
+	for i := 0; i < 200; i++ {
+		_, err := store.Get(fmt.Sprint("txn-", i%20))
+		assert.NoError(t, err)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,153 @@
+// Package observe reports what the keypairgen, encryption and decryption
+// handlers do to a Hook: every operation and every stage within it (ECDH,
+// HKDF, sealing, ...) is started and ended with its duration, outcome and,
+// for whole operations, payload sizes.
+//
+// Events never carry key material, nonces, plaintext or ciphertext, only
+// names, sizes, durations and errors, so hooks can log and export them
+// freely. Logger writes them to a log/slog logger, Metrics aggregates them
+// into Prometheus counters and histograms and Tracing turns them into spans.
+package observe
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// Operation names a handler method.
+type Operation string
+
+const (
+	Generate Operation = "generate"
+	Encrypt  Operation = "encrypt"
+	Decrypt  Operation = "decrypt"
+)
+
+// Stage names a step of an operation. Events for the operation as a whole
+// have no stage.
+type Stage string
+
+const (
+	StageKeyGen     Stage = "keygen"     // private key and scalar multiplication
+	StageEncode     Stage = "encode"     // encoding key material and nonce
+	StageCompress   Stage = "compress"   // compressing the plaintext
+	StageECDH       Stage = "ecdh"       // computing the shared secret
+	StageHKDF       Stage = "hkdf"       // deriving the AES key
+	StageSeal       Stage = "seal"       // AES-GCM encryption
+	StageOpen       Stage = "open"       // AES-GCM decryption
+	StageDecompress Stage = "decompress" // decompressing the plaintext
+)
+
+// Event describes an operation or stage. Start receives it with only
+// Operation, Stage and Curve set; End also gets Duration and Err, and for
+// whole operations the payload sizes.
+type Event struct {
+	Operation Operation
+	Stage     Stage
+	Curve     string
+	Duration  time.Duration
+	Err       error
+	// PlaintextSize and CiphertextSize are the decoded payload lengths in
+	// bytes, when known. The plaintext is measured before compression.
+	PlaintextSize  int
+	CiphertextSize int
+}
+
+// Code returns utils.ErrorCode(e.Err), "CANCELED" or "DEADLINE_EXCEEDED"
+// for context errors, and "" if the event succeeded.
+func (e Event) Code() string {
+	switch {
+	case e.Err == nil:
+		return ""
+	case errors.Is(e.Err, context.Canceled):
+		return "CANCELED"
+	case errors.Is(e.Err, context.DeadlineExceeded):
+		return "DEADLINE_EXCEEDED"
+	default:
+		return utils.ErrorCode(e.Err)
+	}
+}
+
+// Hook observes handler operations. Start is called when an operation or
+// stage begins and returns the context passed to the stages within it and
+// to End, so a hook can carry a span or request-scoped values; it must
+// return ctx if it has nothing to add. Hooks must be safe for concurrent use.
+type Hook interface {
+	Start(ctx context.Context, e Event) context.Context
+	End(ctx context.Context, e Event)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    Multi                                   */
+/* -------------------------------------------------------------------------- */
+// returns a Hook that calls each of hooks in order; nil hooks are skipped.
+func Multi(hooks ...Hook) Hook {
+	var m multiHook
+	for _, h := range hooks {
+		if h != nil {
+			m = append(m, h)
+		}
+	}
+	return m
+}
+
+type multiHook []Hook
+
+func (m multiHook) Start(ctx context.Context, e Event) context.Context {
+	for _, h := range m {
+		ctx = h.Start(ctx, e)
+	}
+	return ctx
+}
+
+func (m multiHook) End(ctx context.Context, e Event) {
+	for i := len(m) - 1; i >= 0; i-- {
+		m[i].End(ctx, e)
+	}
+}
+
+// Span tracks an operation or stage between Start and End. A nil *Span is
+// valid and does nothing, so handlers without a hook pay almost nothing.
+type Span struct {
+	hook  Hook
+	ctx   context.Context
+	event Event
+	start time.Time
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    Start                                   */
+/* -------------------------------------------------------------------------- */
+// reports e to hook and returns the context for everything within it and a
+// Span to end it with. With a nil hook it returns ctx and a nil Span.
+func Start(ctx context.Context, hook Hook, e Event) (context.Context, *Span) {
+	if hook == nil {
+		return ctx, nil
+	}
+	ctx = hook.Start(ctx, e)
+	return ctx, &Span{hook: hook, ctx: ctx, event: e, start: time.Now()}
+}
+
+// SetSizes records the payload sizes reported when s ends.
+func (s *Span) SetSizes(plaintext, ciphertext int) {
+	if s == nil {
+		return
+	}
+	s.event.PlaintextSize = plaintext
+	s.event.CiphertextSize = ciphertext
+}
+
+// End reports the span's duration and err to its hook. Only the first call
+// has any effect.
+func (s *Span) End(err error) {
+	if s == nil || s.hook == nil {
+		return
+	}
+	s.event.Duration = time.Since(s.start)
+	s.event.Err = err
+	s.hook.End(s.ctx, s.event)
+	s.hook = nil
+}
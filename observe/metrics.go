@@ -0,0 +1,244 @@
+package observe
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metric names exported by Metrics.
+const (
+	MetricOperations        = "fidelius_operations_total"
+	MetricOperationDuration = "fidelius_operation_duration_seconds"
+	MetricStageDuration     = "fidelius_stage_duration_seconds"
+	MetricPayloadSize       = "fidelius_payload_bytes"
+)
+
+var (
+	// durationBuckets spans 10µs to about 2.6s, enough to tell an X25519
+	// ECDH from a math/big one and both from AES-GCM over small payloads.
+	durationBuckets = exponentialBuckets(10e-6, 4, 10)
+	// sizeBuckets spans 64 bytes to 16 MiB.
+	sizeBuckets = exponentialBuckets(64, 4, 10)
+)
+
+// Metrics is a Hook that aggregates events into Prometheus counters and
+// histograms in memory:
+//
+//   - fidelius_operations_total{operation,curve,code} counts finished
+//     operations, with code "OK" or the failed event's Code.
+//   - fidelius_operation_duration_seconds{operation,curve} and
+//     fidelius_stage_duration_seconds{operation,stage} time operations and
+//     their stages.
+//   - fidelius_payload_bytes{operation,payload} measures the plaintext and
+//     ciphertext of successful encryptions and decryptions.
+//
+// Metrics serves them in the Prometheus text format over HTTP, or writes
+// them with WriteTo. It is safe for concurrent use.
+type Metrics struct {
+	mu       sync.Mutex
+	families []*family
+}
+
+type family struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64 // nil for counters
+	series  map[string]*series
+}
+
+type series struct {
+	values  []string
+	count   uint64
+	sum     float64
+	buckets []uint64 // per-bucket counts, not cumulative
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 NewMetrics                                 */
+/* -------------------------------------------------------------------------- */
+// returns an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		families: []*family{
+			newFamily(MetricOperations, "Finished operations by outcome.", nil, "operation", "curve", "code"),
+			newFamily(MetricOperationDuration, "Operation latency in seconds.", durationBuckets, "operation", "curve"),
+			newFamily(MetricStageDuration, "Stage latency in seconds.", durationBuckets, "operation", "stage"),
+			newFamily(MetricPayloadSize, "Payload size in bytes.", sizeBuckets, "operation", "payload"),
+		},
+	}
+}
+
+func newFamily(name, help string, buckets []float64, labels ...string) *family {
+	return &family{name: name, help: help, labels: labels, buckets: buckets, series: map[string]*series{}}
+}
+
+func (m *Metrics) Start(ctx context.Context, e Event) context.Context {
+	return ctx
+}
+
+func (m *Metrics) End(ctx context.Context, e Event) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	op := string(e.Operation)
+	if e.Stage != "" {
+		m.family(MetricStageDuration).observe(e.Duration.Seconds(), op, string(e.Stage))
+		return
+	}
+	code := e.Code()
+	if code == "" {
+		code = "OK"
+	}
+	m.family(MetricOperations).observe(1, op, e.Curve, code)
+	m.family(MetricOperationDuration).observe(e.Duration.Seconds(), op, e.Curve)
+	if e.Err == nil && e.Operation != Generate {
+		m.family(MetricPayloadSize).observe(float64(e.PlaintextSize), op, "plaintext")
+		m.family(MetricPayloadSize).observe(float64(e.CiphertextSize), op, "ciphertext")
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    Count                                   */
+/* -------------------------------------------------------------------------- */
+// returns the value of a counter, or the number of observations of a
+// histogram, for the given label values; 0 if there are none.
+func (m *Metrics) Count(name string, labelValues ...string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f := m.family(name)
+	if f == nil {
+		return 0
+	}
+	s := f.series[seriesKey(labelValues)]
+	if s == nil {
+		return 0
+	}
+	return s.count
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   WriteTo                                  */
+/* -------------------------------------------------------------------------- */
+// writes all metrics to w in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	m.mu.Lock()
+	for _, f := range m.families {
+		f.write(&b)
+	}
+	m.mu.Unlock()
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics for a Prometheus scrape.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w)
+}
+
+func (m *Metrics) family(name string) *family {
+	for _, f := range m.families {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// observe adds v to a counter, or records it in a histogram.
+func (f *family) observe(v float64, labelValues ...string) {
+	key := seriesKey(labelValues)
+	s := f.series[key]
+	if s == nil {
+		s = &series{values: labelValues}
+		if f.buckets != nil {
+			s.buckets = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	if f.buckets == nil {
+		s.count += uint64(v)
+		return
+	}
+	s.count++
+	s.sum += v
+	for i, upper := range f.buckets {
+		if v <= upper {
+			s.buckets[i]++
+			break
+		}
+	}
+}
+
+func (f *family) write(b *strings.Builder) {
+	if len(f.series) == 0 {
+		return
+	}
+	typ := "counter"
+	if f.buckets != nil {
+		typ = "histogram"
+	}
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, typ)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		s := f.series[key]
+		labels := f.labelPairs(s.values)
+		if f.buckets == nil {
+			fmt.Fprintf(b, "%s{%s} %d\n", f.name, labels, s.count)
+			continue
+		}
+		var cumulative uint64
+		for i, upper := range f.buckets {
+			cumulative += s.buckets[i]
+			fmt.Fprintf(b, "%s_bucket{%s,le=%q} %d\n", f.name, labels, formatFloat(upper), cumulative)
+		}
+		fmt.Fprintf(b, "%s_bucket{%s,le=\"+Inf\"} %d\n", f.name, labels, s.count)
+		fmt.Fprintf(b, "%s_sum{%s} %s\n", f.name, labels, formatFloat(s.sum))
+		fmt.Fprintf(b, "%s_count{%s} %d\n", f.name, labels, s.count)
+	}
+}
+
+func (f *family) labelPairs(values []string) string {
+	pairs := make([]string, len(f.labels))
+	for i, label := range f.labels {
+		pairs[i] = label + "=" + escapeLabelValue(values[i])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func seriesKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+// escapeLabelValue quotes a label value as the text format requires:
+// backslash, double quote and newline are escaped.
+func escapeLabelValue(v string) string {
+	v = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+	return `"` + v + `"`
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func exponentialBuckets(start, factor float64, count int) []float64 {
+	buckets := make([]float64, count)
+	for i := range buckets {
+		buckets[i] = start * math.Pow(factor, float64(i))
+	}
+	return buckets
+}
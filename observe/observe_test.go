@@ -0,0 +1,194 @@
+package observe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// recorder is a Hook that records the events it sees.
+type recorder struct {
+	name   string
+	events *[]string
+}
+
+type recorderKey struct{}
+
+func (r recorder) Start(ctx context.Context, e Event) context.Context {
+	*r.events = append(*r.events, r.name+" start "+string(e.Stage))
+	return context.WithValue(ctx, recorderKey{}, r.name)
+}
+
+func (r recorder) End(ctx context.Context, e Event) {
+	*r.events = append(*r.events, fmt.Sprintf("%s end %s %v", r.name, e.Stage, ctx.Value(recorderKey{})))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               Tests for Start                              */
+/* -------------------------------------------------------------------------- */
+func TestStart(t *testing.T) {
+	// without a hook, spans are nil and do nothing
+	ctx, span := Start(context.Background(), nil, Event{Operation: Encrypt})
+	assert.Nil(t, span)
+	assert.Equal(t, context.Background(), ctx)
+	span.SetSizes(1, 2)
+	span.End(nil)
+
+	var events []string
+	hook := recorder{name: "a", events: &events}
+	ctx, span = Start(context.Background(), hook, Event{Operation: Encrypt})
+	_, stage := Start(ctx, hook, Event{Operation: Encrypt, Stage: StageECDH})
+	stage.End(nil)
+	stage.End(nil) // ends only once
+	span.End(nil)
+	assert.Equal(t, []string{"a start ", "a start ecdh", "a end ecdh a", "a end  a"}, events)
+}
+
+func TestMulti(t *testing.T) {
+	var events []string
+	hook := Multi(recorder{name: "a", events: &events}, nil, recorder{name: "b", events: &events})
+	_, span := Start(context.Background(), hook, Event{Operation: Decrypt, Stage: StageOpen})
+	span.End(nil)
+	// hooks start in order and end in reverse, like nested spans
+	assert.Equal(t, []string{"a start open", "b start open", "b end open b", "a end open b"}, events)
+}
+
+func TestEventCode(t *testing.T) {
+	assert.Equal(t, "", Event{}.Code())
+	assert.Equal(t, "CANCELED", Event{Err: fmt.Errorf("x: %w", context.Canceled)}.Code())
+	assert.Equal(t, "DEADLINE_EXCEEDED", Event{Err: context.DeadlineExceeded}.Code())
+	assert.Equal(t, "DECRYPTION_FAILED", Event{Err: utils.WithKind(utils.ErrDecryptionFailed, errors.New("x"))}.Code())
+	assert.Equal(t, "INTERNAL", Event{Err: errors.New("x")}.Code())
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Tests for Logger                              */
+/* -------------------------------------------------------------------------- */
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	hook := Logger(logger)
+
+	ctx, span := Start(context.Background(), hook, Event{Operation: Decrypt, Curve: "curve25519"})
+	_, stage := Start(ctx, hook, Event{Operation: Decrypt, Stage: StageOpen, Curve: "curve25519"})
+	err := utils.WithKind(utils.ErrDecryptionFailed, errors.New("message authentication failed"))
+	stage.End(err)
+	span.SetSizes(0, 42)
+	span.End(err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	var records []map[string]any
+	for _, line := range lines {
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, "fidelius decrypt open", records[0]["msg"])
+	assert.Equal(t, "open", records[0]["stage"])
+	assert.Equal(t, "DECRYPTION_FAILED", records[0]["error_code"])
+
+	assert.Equal(t, "WARN", records[1]["level"])
+	assert.Equal(t, "fidelius decrypt", records[1]["msg"])
+	assert.Equal(t, "curve25519", records[1]["curve"])
+	assert.Equal(t, float64(42), records[1]["ciphertext_size"])
+	assert.Equal(t, "message authentication failed", records[1]["error"])
+
+	// stages are skipped unless debug logging is enabled
+	buf.Reset()
+	hook = Logger(slog.New(slog.NewJSONHandler(&buf, nil)))
+	ctx, span = Start(context.Background(), hook, Event{Operation: Generate})
+	_, stage = Start(ctx, hook, Event{Operation: Generate, Stage: StageKeyGen})
+	stage.End(nil)
+	span.End(nil)
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+	assert.Contains(t, buf.String(), `"level":"INFO"`)
+	assert.NotContains(t, buf.String(), "plaintext_size")
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Tests for Metrics                             */
+/* -------------------------------------------------------------------------- */
+func TestMetrics(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.End(context.Background(), Event{Operation: Encrypt, Curve: "curve25519", Duration: time.Millisecond, PlaintextSize: 100, CiphertextSize: 116})
+	metrics.End(context.Background(), Event{Operation: Encrypt, Curve: "curve25519", Duration: time.Millisecond, PlaintextSize: 1000, CiphertextSize: 1016})
+	metrics.End(context.Background(), Event{Operation: Encrypt, Curve: "curve25519", Err: utils.WithKind(utils.ErrInvalidInput, errors.New("x"))})
+	metrics.End(context.Background(), Event{Operation: Encrypt, Stage: StageECDH, Duration: 50 * time.Microsecond})
+
+	assert.Equal(t, uint64(2), metrics.Count(MetricOperations, "encrypt", "curve25519", "OK"))
+	assert.Equal(t, uint64(1), metrics.Count(MetricOperations, "encrypt", "curve25519", "INVALID_INPUT"))
+	assert.Equal(t, uint64(3), metrics.Count(MetricOperationDuration, "encrypt", "curve25519"))
+	assert.Equal(t, uint64(1), metrics.Count(MetricStageDuration, "encrypt", "ecdh"))
+	assert.Equal(t, uint64(2), metrics.Count(MetricPayloadSize, "encrypt", "plaintext"))
+	assert.Equal(t, uint64(0), metrics.Count(MetricPayloadSize, "decrypt", "plaintext"))
+	assert.Equal(t, uint64(0), metrics.Count("unknown"))
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	out := rec.Body.String()
+	assert.Contains(t, out, "# TYPE fidelius_operations_total counter\n")
+	assert.Contains(t, out, `fidelius_operations_total{operation="encrypt",curve="curve25519",code="OK"} 2`)
+	assert.Contains(t, out, "# TYPE fidelius_payload_bytes histogram\n")
+	// 100 falls in the 256 bucket, 1000 in the 1024 one; buckets are cumulative
+	assert.Contains(t, out, `fidelius_payload_bytes_bucket{operation="encrypt",payload="plaintext",le="64"} 0`)
+	assert.Contains(t, out, `fidelius_payload_bytes_bucket{operation="encrypt",payload="plaintext",le="256"} 1`)
+	assert.Contains(t, out, `fidelius_payload_bytes_bucket{operation="encrypt",payload="plaintext",le="1024"} 2`)
+	assert.Contains(t, out, `fidelius_payload_bytes_bucket{operation="encrypt",payload="plaintext",le="+Inf"} 2`)
+	assert.Contains(t, out, `fidelius_payload_bytes_sum{operation="encrypt",payload="plaintext"} 1100`)
+	assert.Contains(t, out, `fidelius_stage_duration_seconds_count{operation="encrypt",stage="ecdh"} 1`)
+}
+
+func TestEscapeLabelValue(t *testing.T) {
+	assert.Equal(t, `"a\\b\"c\nd"`, escapeLabelValue("a\\b\"c\nd"))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              Tests for Tracing                             */
+/* -------------------------------------------------------------------------- */
+func TestTracing(t *testing.T) {
+	tracer := NewMemoryTracer()
+	hook := Tracing(tracer)
+
+	ctx, span := Start(context.Background(), hook, Event{Operation: Encrypt, Curve: "P-256"})
+	_, stage := Start(ctx, hook, Event{Operation: Encrypt, Stage: StageECDH, Curve: "P-256"})
+	stage.End(nil)
+	_, stage = Start(ctx, hook, Event{Operation: Encrypt, Stage: StageSeal, Curve: "P-256"})
+	err := errors.New("seal failed")
+	stage.End(err)
+	span.End(err)
+
+	spans := tracer.Spans()
+	assert.Len(t, spans, 3)
+	assert.Equal(t, "fidelius.encrypt.ecdh", spans[0].Name)
+	assert.Equal(t, "fidelius.encrypt.seal", spans[1].Name)
+	assert.Equal(t, "fidelius.encrypt", spans[2].Name)
+	assert.Same(t, spans[2], spans[0].Parent)
+	assert.Same(t, spans[2], spans[1].Parent)
+	assert.Nil(t, spans[2].Parent)
+
+	assert.Nil(t, spans[0].Err)
+	assert.Equal(t, err, spans[1].Err)
+	curve, ok := spans[2].Attribute("fidelius.curve")
+	assert.True(t, ok)
+	assert.Equal(t, "P-256", curve.String())
+	code, ok := spans[2].Attribute("fidelius.error_code")
+	assert.True(t, ok)
+	assert.Equal(t, "INTERNAL", code.String())
+	_, ok = spans[0].Attribute("fidelius.plaintext_size")
+	assert.False(t, ok)
+	assert.False(t, spans[2].EndTime.Before(spans[2].Start))
+}
@@ -0,0 +1,63 @@
+package observe
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogHook struct {
+	logger *slog.Logger
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Logger                                   */
+/* -------------------------------------------------------------------------- */
+// returns a Hook that logs each finished operation to logger at Info, or at
+// Warn if it failed, and each finished stage at Debug. Records are logged
+// with the hook's context, so handlers can add request-scoped values such as
+// trace IDs. A nil logger uses slog.Default().
+func Logger(logger *slog.Logger) Hook {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return slogHook{logger: logger}
+}
+
+func (h slogHook) Start(ctx context.Context, e Event) context.Context {
+	return ctx
+}
+
+func (h slogHook) End(ctx context.Context, e Event) {
+	level := slog.LevelInfo
+	msg := "fidelius " + string(e.Operation)
+	if e.Stage != "" {
+		level = slog.LevelDebug
+		msg += " " + string(e.Stage)
+	} else if e.Err != nil {
+		level = slog.LevelWarn
+	}
+	if !h.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", string(e.Operation)),
+		slog.String("curve", e.Curve),
+		slog.Duration("duration", e.Duration),
+	}
+	if e.Stage != "" {
+		attrs = append(attrs, slog.String("stage", string(e.Stage)))
+	} else if e.Operation != Generate {
+		attrs = append(attrs,
+			slog.Int("plaintext_size", e.PlaintextSize),
+			slog.Int("ciphertext_size", e.CiphertextSize),
+		)
+	}
+	if e.Err != nil {
+		attrs = append(attrs,
+			slog.String("error_code", e.Code()),
+			slog.String("error", e.Err.Error()),
+		)
+	}
+	h.logger.LogAttrs(ctx, level, msg, attrs...)
+}
@@ -0,0 +1,134 @@
+package observe
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Tracer starts spans. It is the subset of an OpenTelemetry trace.Tracer
+// this package needs; adapting one takes a few lines (see the readme), which
+// keeps OpenTelemetry out of this module's dependencies.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, TraceSpan)
+}
+
+// TraceSpan is the subset of an OpenTelemetry trace.Span this package needs.
+type TraceSpan interface {
+	SetAttributes(attrs ...slog.Attr)
+	// RecordError marks the span as failed with err.
+	RecordError(err error)
+	End()
+}
+
+type tracingHook struct {
+	tracer Tracer
+}
+
+type traceSpanKey struct{}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Tracing                                  */
+/* -------------------------------------------------------------------------- */
+// returns a Hook that starts a span named "fidelius.<operation>" for every
+// operation, with a child "fidelius.<operation>.<stage>" for every stage.
+// Spans carry the curve, payload sizes and, on failure, the error code.
+func Tracing(tracer Tracer) Hook {
+	return tracingHook{tracer: tracer}
+}
+
+func (h tracingHook) Start(ctx context.Context, e Event) context.Context {
+	name := "fidelius." + string(e.Operation)
+	if e.Stage != "" {
+		name += "." + string(e.Stage)
+	}
+	ctx, span := h.tracer.Start(ctx, name)
+	span.SetAttributes(slog.String("fidelius.curve", e.Curve))
+	return context.WithValue(ctx, traceSpanKey{}, span)
+}
+
+func (h tracingHook) End(ctx context.Context, e Event) {
+	span, ok := ctx.Value(traceSpanKey{}).(TraceSpan)
+	if !ok {
+		return
+	}
+	if e.Stage == "" && e.Operation != Generate {
+		span.SetAttributes(
+			slog.Int("fidelius.plaintext_size", e.PlaintextSize),
+			slog.Int("fidelius.ciphertext_size", e.CiphertextSize),
+		)
+	}
+	if e.Err != nil {
+		span.SetAttributes(slog.String("fidelius.error_code", e.Code()))
+		span.RecordError(e.Err)
+	}
+	span.End()
+}
+
+// MemoryTracer is a Tracer that keeps finished spans in memory, for tests.
+// It is safe for concurrent use.
+type MemoryTracer struct {
+	mu    sync.Mutex
+	spans []*MemorySpan
+}
+
+// MemorySpan is a span recorded by a MemoryTracer.
+type MemorySpan struct {
+	Name       string
+	Parent     *MemorySpan // nil for root spans
+	Attributes []slog.Attr
+	Err        error
+	Start      time.Time
+	EndTime    time.Time
+
+	tracer *MemoryTracer
+}
+
+type memorySpanKey struct{}
+
+/* -------------------------------------------------------------------------- */
+/*                               NewMemoryTracer                              */
+/* -------------------------------------------------------------------------- */
+// returns a MemoryTracer with no spans.
+func NewMemoryTracer() *MemoryTracer {
+	return &MemoryTracer{}
+}
+
+func (t *MemoryTracer) Start(ctx context.Context, name string) (context.Context, TraceSpan) {
+	parent, _ := ctx.Value(memorySpanKey{}).(*MemorySpan)
+	span := &MemorySpan{Name: name, Parent: parent, Start: time.Now(), tracer: t}
+	return context.WithValue(ctx, memorySpanKey{}, span), span
+}
+
+// Spans returns the finished spans in the order they ended.
+func (t *MemoryTracer) Spans() []*MemorySpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*MemorySpan(nil), t.spans...)
+}
+
+// Attribute returns the value of the attribute named key, if set.
+func (s *MemorySpan) Attribute(key string) (slog.Value, bool) {
+	for i := len(s.Attributes) - 1; i >= 0; i-- {
+		if s.Attributes[i].Key == key {
+			return s.Attributes[i].Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+func (s *MemorySpan) SetAttributes(attrs ...slog.Attr) {
+	s.Attributes = append(s.Attributes, attrs...)
+}
+
+func (s *MemorySpan) RecordError(err error) {
+	s.Err = err
+}
+
+func (s *MemorySpan) End() {
+	s.EndTime = time.Now()
+	s.tracer.mu.Lock()
+	s.tracer.spans = append(s.tracer.spans, s)
+	s.tracer.mu.Unlock()
+}
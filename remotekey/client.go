@@ -0,0 +1,84 @@
+package remotekey
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+const dialTimeout = 5 * time.Second
+
+type remoteKey struct {
+	SocketPath string
+	Curve      *utils.Curve
+	publicKey  *utils.Point
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                    Dial                                    */
+/* -------------------------------------------------------------------------- */
+// returns an ECDHKey whose operations are performed by the key server
+// listening on socketPath. The public key is fetched once and cached.
+func Dial(socketPath string, curve *utils.Curve) (*remoteKey, error) {
+	key := &remoteKey{SocketPath: socketPath, Curve: curve}
+	resp, err := key.call(request{Op: opPublicKey})
+	if err != nil {
+		return nil, err
+	}
+	key.publicKey, err = utils.DecodeBase64ToPublicKey(resp.PublicKey, curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Dial][utils.DecodeBase64ToPublicKey]")
+	}
+	return key, nil
+}
+
+func (k *remoteKey) PublicKey() (*utils.Point, error) {
+	return k.publicKey, nil
+}
+
+func (k *remoteKey) SharedSecret(peerPublicKey *utils.Point) (*utils.Secret, error) {
+	if peerPublicKey == nil || peerPublicKey.X == nil || peerPublicKey.Y == nil {
+		return nil, utils.WithKind(utils.ErrInvalidKey, errors.New("peer public key is missing"))
+	}
+	resp, err := k.call(request{
+		Op:            opSharedSecret,
+		PeerPublicKey: utils.EncodeCurvePublicKeyToBase64(k.Curve, peerPublicKey.X, peerPublicKey.Y),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := utils.DecodeBase64(resp.SharedSecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "[SharedSecret][utils.DecodeBase64]")
+	}
+	return utils.NewSecret(sharedSecret), nil
+}
+
+// call performs a single request on a fresh connection.
+func (k *remoteKey) call(req request) (*response, error) {
+	conn, err := net.DialTimeout("unix", k.SocketPath, dialTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "[call][net.DialTimeout]")
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(dialTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, errors.Wrap(err, "[call][Encode]")
+	}
+	resp := &response{}
+	if err := json.NewDecoder(conn).Decode(resp); err != nil {
+		return nil, errors.Wrap(err, "[call][Decode]")
+	}
+	if resp.Error != "" {
+		err := errors.New("remote key: " + resp.Error)
+		if kind := utils.KindFromCode(resp.Code); kind != nil {
+			return nil, utils.WithKind(kind, err)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,28 @@
+package remotekey
+
+import "github.com/zoop/fidelius-go/utils"
+
+const (
+	opPublicKey    = "publicKey"
+	opSharedSecret = "sharedSecret"
+)
+
+// request is one line of the JSON protocol spoken over the socket.
+type request struct {
+	Op            string `json:"op"`
+	PeerPublicKey string `json:"peerPublicKey,omitempty"`
+}
+
+// response answers a single request; Error is set when the operation failed
+// and Code carries its utils.ErrorCode, so the client can restore the kind.
+type response struct {
+	PublicKey    string `json:"publicKey,omitempty"`
+	SharedSecret string `json:"sharedSecret,omitempty"`
+	Error        string `json:"error,omitempty"`
+	Code         string `json:"code,omitempty"`
+}
+
+// errorResponse reports err together with its error code.
+func errorResponse(err error) response {
+	return response{Error: err.Error(), Code: utils.ErrorCode(err)}
+}
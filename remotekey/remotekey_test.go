@@ -0,0 +1,116 @@
+package remotekey
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/decryption"
+	"github.com/zoop/fidelius-go/encryption"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                             Tests for RemoteKey                            */
+/* -------------------------------------------------------------------------- */
+func TestRemoteKey(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyHandler := keypairgen.Handler(BC25519)
+	senderKeys, err := keyHandler.Generate()
+	assert.NoError(t, err)
+	requesterKeys, err := keyHandler.Generate()
+	assert.NoError(t, err)
+
+	/* ------------------------ Sender Key In Key Server ------------------------ */
+	dir, err := os.MkdirTemp("", "remotekey")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	socketPath := filepath.Join(dir, "key.sock")
+	ln, err := net.Listen("unix", socketPath)
+	assert.NoError(t, err)
+	defer ln.Close()
+	localKey, err := utils.NewPrivateKeyECDH(BC25519, senderKeys.PrivateKey)
+	assert.NoError(t, err)
+	go Serve(ln, localKey, BC25519)
+
+	remote, err := Dial(socketPath, BC25519)
+	assert.NoError(t, err)
+	publicKey, err := remote.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, senderKeys.PublicKey, utils.EncodePublicKeyToBase64(publicKey.X, publicKey.Y))
+
+	/* --------------------- Encrypt Without The Private Key --------------------- */
+	dataToEncrypt := "Hello, World!"
+	encrypted, err := encryption.Handler(BC25519).Encrypt(encryption.EncryptionRequest{
+		StringToEncrypt:    dataToEncrypt,
+		SenderNonce:        senderKeys.Nonce,
+		RequesterNonce:     requesterKeys.Nonce,
+		SenderKey:          remote,
+		RequesterPublicKey: requesterKeys.PublicKey,
+	})
+	assert.NoError(t, err)
+
+	decrypted, err := decryption.Handler(BC25519).Decrypt(decryption.DecryptionRequest{
+		EncryptedData:       encrypted,
+		SenderNonce:         senderKeys.Nonce,
+		RequesterNonce:      requesterKeys.Nonce,
+		RequesterPrivateKey: requesterKeys.PrivateKey,
+		SenderPublicKey:     senderKeys.PublicKey,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, dataToEncrypt, decrypted)
+
+	/* ---------------------- Server Errors Reach The Caller --------------------- */
+	_, err = remote.SharedSecret(&utils.Point{X: publicKey.X, Y: publicKey.X, Curve: BC25519})
+	assert.ErrorIs(t, err, utils.ErrInvalidKey)
+	assert.Contains(t, err.Error(), "remote key: ")
+	_, err = remote.SharedSecret(nil)
+	assert.ErrorIs(t, err, utils.ErrInvalidKey)
+	_, err = remote.SharedSecret(&utils.Point{Curve: BC25519})
+	assert.ErrorIs(t, err, utils.ErrInvalidKey)
+	_, err = remote.call(request{Op: "unknown"})
+	assert.ErrorIs(t, err, utils.ErrInvalidInput)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for ListenAndServe                          */
+/* -------------------------------------------------------------------------- */
+func TestListenAndServe(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	keyMaterial, err := keypairgen.Handler(BC25519).Generate()
+	assert.NoError(t, err)
+	localKey, err := utils.NewPrivateKeyECDH(BC25519, keyMaterial.PrivateKey)
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	socketPath := filepath.Join(dir, "key.sock")
+	go ListenAndServe(socketPath, localKey, BC25519)
+
+	var info os.FileInfo
+	assert.Eventually(t, func() bool {
+		info, err = os.Lstat(socketPath)
+		return err == nil
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, os.ModeSocket|0o600, info.Mode()&(os.ModeSocket|os.ModePerm))
+
+	remote, err := Dial(socketPath, BC25519)
+	assert.NoError(t, err)
+	publicKey, err := remote.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, keyMaterial.PublicKey, utils.EncodePublicKeyToBase64(publicKey.X, publicKey.Y))
+
+	// an existing path is never replaced
+	assert.Error(t, ListenAndServe(socketPath, localKey, BC25519))
+	filePath := filepath.Join(dir, "file")
+	assert.NoError(t, os.WriteFile(filePath, []byte("keep"), 0o600))
+	assert.Error(t, ListenAndServe(filePath, localKey, BC25519))
+	content, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Equal(t, "keep", string(content))
+}
@@ -0,0 +1,110 @@
+package remotekey
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// maxRequestSize bounds a single request line; requests only carry a public key.
+const maxRequestSize = 4096
+
+/* -------------------------------------------------------------------------- */
+/*                                    Serve                                   */
+/* -------------------------------------------------------------------------- */
+// answers public key and shared secret requests for key on ln until ln is
+// closed. The private key itself never leaves this process.
+func Serve(ln net.Listener, key utils.ECDHKey, curve *utils.Curve) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveConn(conn, key, curve)
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               ListenAndServe                               */
+/* -------------------------------------------------------------------------- */
+// serves key on a Unix socket at socketPath that only the owner can connect to.
+// The socket is created inside a private (0700) directory, restricted to
+// 0600 and only then hard-linked to socketPath, so nobody else can connect
+// while its permissions are still those of the umask. Linking fails if
+// socketPath already exists, so an existing file is never replaced.
+func ListenAndServe(socketPath string, key utils.ECDHKey, curve *utils.Curve) error {
+	dir, err := os.MkdirTemp(filepath.Dir(socketPath), ".remotekey-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	privatePath := filepath.Join(dir, "sock")
+
+	ln, err := net.Listen("unix", privatePath)
+	if err != nil {
+		return err
+	}
+	// the socket is unlinked below from its final path instead
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	defer ln.Close()
+	if err := os.Chmod(privatePath, 0o600); err != nil {
+		return err
+	}
+	if err := os.Link(privatePath, socketPath); err != nil {
+		return err
+	}
+	defer os.Remove(socketPath)
+	if err := os.Remove(privatePath); err != nil {
+		return err
+	}
+	return Serve(ln, key, curve)
+}
+
+func serveConn(conn net.Conn, key utils.ECDHKey, curve *utils.Curve) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, maxRequestSize), maxRequestSize)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			encoder.Encode(errorResponse(utils.WithKind(utils.ErrInvalidInput, errors.New("malformed request"))))
+			return
+		}
+		if err := encoder.Encode(handle(req, key, curve)); err != nil {
+			return
+		}
+	}
+}
+
+func handle(req request, key utils.ECDHKey, curve *utils.Curve) response {
+	switch req.Op {
+	case opPublicKey:
+		publicKey, err := key.PublicKey()
+		if err != nil {
+			return errorResponse(err)
+		}
+		return response{PublicKey: utils.EncodeCurvePublicKeyToBase64(curve, publicKey.X, publicKey.Y)}
+	case opSharedSecret:
+		peerPublicKey, err := utils.DecodeBase64ToPublicKey(req.PeerPublicKey, curve)
+		if err != nil {
+			return errorResponse(err)
+		}
+		sharedSecret, err := key.SharedSecret(peerPublicKey)
+		if err != nil {
+			return errorResponse(err)
+		}
+		defer sharedSecret.Destroy()
+		return response{SharedSecret: utils.EncodeBase64(sharedSecret.Bytes())}
+	default:
+		return errorResponse(utils.WithKind(utils.ErrInvalidInput, errors.New("unknown operation")))
+	}
+}
@@ -0,0 +1,36 @@
+#!/bin/sh
+# Runs the crypto benchmarks and compares them with the stored baseline.
+#
+#   scripts/bench.sh            run and compare against testdata/bench/baseline.txt
+#   scripts/bench.sh -update    run and store the result as the new baseline
+#
+# COUNT (default 6), BENCHTIME (default 1s) and BENCH (default ".") are passed
+# to go test. Results are written to bench_output.txt in benchstat's input
+# format; the comparison needs benchstat on PATH:
+#
+#   go install golang.org/x/perf/cmd/benchstat@latest
+#
+# The baseline is machine specific. Regenerate it on the machine you compare
+# on before drawing conclusions.
+set -eu
+
+cd "$(dirname "$0")/.."
+
+baseline=testdata/bench/baseline.txt
+output=bench_output.txt
+packages="./utils ./encryption ./decryption"
+
+go test -run '^$' -bench "${BENCH:-.}" -benchmem \
+	-count "${COUNT:-6}" -benchtime "${BENCHTIME:-1s}" $packages | tee "$output"
+
+if [ "${1:-}" = "-update" ]; then
+	cp "$output" "$baseline"
+	echo "baseline updated: $baseline"
+	exit 0
+fi
+
+if ! command -v benchstat >/dev/null 2>&1; then
+	echo "benchstat not found; install it with: go install golang.org/x/perf/cmd/benchstat@latest" >&2
+	exit 1
+fi
+benchstat "$baseline" "$output"
@@ -0,0 +1,188 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"errors"
+	"hash"
+	"math/big"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                                    Sign                                    */
+/* -------------------------------------------------------------------------- */
+// signs message with the base64 encoded private key. The nonce is derived
+// from the key and the message digest (RFC 6979), so signing the same
+// message twice gives the same signature.
+func (h *signingHandler) Sign(privateKey string, message []byte) (*Signature, error) {
+	digest := h.hash()()
+	digest.Write(message)
+	return h.SignDigest(privateKey, digest.Sum(nil))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 SignDigest                                 */
+/* -------------------------------------------------------------------------- */
+// signs a precomputed message digest made with the curve's hash.
+func (h *signingHandler) SignDigest(privateKey string, digest []byte) (*Signature, error) {
+	q := h.Curve.Q
+	x, err := utils.DecodeBase64ToPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.ZeroizeBigInt(x)
+	if x.Sign() <= 0 || x.Cmp(q) >= 0 {
+		return nil, utils.WithKind(utils.ErrInvalidKey, errors.New("invalid private key"))
+	}
+
+	e := bitsToInt(digest, q.BitLen())
+	nextK := rfc6979(q, x, digest, h.hash())
+	for {
+		k := nextK()
+		rx, _, err := utils.GeneratePublicKey(h.Curve, k)
+		if err != nil {
+			utils.ZeroizeBigInt(k)
+			return nil, err
+		}
+		r := rx.Mod(rx, q)
+		if r.Sign() == 0 {
+			utils.ZeroizeBigInt(k)
+			continue
+		}
+		// s = k⁻¹(e + r·x) mod q
+		s := new(big.Int).Mul(r, x)
+		s.Add(s, e)
+		kInv := k.ModInverse(k, q)
+		s.Mul(s, kInv)
+		s.Mod(s, q)
+		utils.ZeroizeBigInt(kInv)
+		if s.Sign() == 0 {
+			continue
+		}
+		return &Signature{R: r, S: s}, nil
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Verify                                   */
+/* -------------------------------------------------------------------------- */
+// checks signature over message against the base64 encoded public key
+// (uncompressed or X.509). It returns ErrInvalidSignature if it doesn't
+// match.
+func (h *signingHandler) Verify(publicKey string, message []byte, signature *Signature) error {
+	digest := h.hash()()
+	digest.Write(message)
+	return h.VerifyDigest(publicKey, digest.Sum(nil), signature)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                VerifyDigest                                */
+/* -------------------------------------------------------------------------- */
+// checks signature over a precomputed message digest.
+func (h *signingHandler) VerifyDigest(publicKey string, digest []byte, signature *Signature) error {
+	point, err := utils.DecodeBase64ToPublicKey(publicKey, h.Curve)
+	if err != nil {
+		return err
+	}
+	q := h.Curve.Q
+	if signature == nil || signature.R == nil || signature.S == nil ||
+		signature.R.Sign() <= 0 || signature.R.Cmp(q) >= 0 ||
+		signature.S.Sign() <= 0 || signature.S.Cmp(q) >= 0 {
+		return ErrInvalidSignature
+	}
+
+	// (x, y) = u1·G + u2·Q with w = s⁻¹, u1 = e·w, u2 = r·w
+	e := bitsToInt(digest, q.BitLen())
+	w := new(big.Int).ModInverse(signature.S, q)
+	u1 := new(big.Int).Mul(e, w)
+	u1.Mod(u1, q)
+	u2 := new(big.Int).Mul(signature.R, w)
+	u2.Mod(u2, q)
+
+	base := &utils.Point{X: h.Curve.Gx, Y: h.Curve.Gy, Curve: h.Curve}
+	sum, err := base.ScalarMul(u1).Add(point.ScalarMul(u2))
+	if err != nil || sum == utils.IdentityPoint {
+		return ErrInvalidSignature
+	}
+	if new(big.Int).Mod(sum.X, q).Cmp(signature.R) != 0 {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// rfc6979 returns a generator of the candidate nonces of RFC 6979 section
+// 3.2 for private key x and message digest h1. Each call returns the next
+// candidate in [1, q).
+func rfc6979(q, x *big.Int, h1 []byte, hashFn func() hash.Hash) func() *big.Int {
+	qlen := q.BitLen()
+	rlen := (qlen + 7) / 8
+	hlen := hashFn().Size()
+
+	v := make([]byte, hlen)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k := make([]byte, hlen)
+
+	mac := func(key []byte, parts ...[]byte) []byte {
+		m := hmac.New(hashFn, key)
+		for _, part := range parts {
+			m.Write(part)
+		}
+		return m.Sum(nil)
+	}
+
+	privateKey := intToOctets(x, rlen)
+	message := bitsToOctets(h1, q)
+	defer utils.Zeroize(privateKey)
+	k = mac(k, v, []byte{0x00}, privateKey, message)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, privateKey, message)
+	v = mac(k, v)
+
+	first := true
+	return func() *big.Int {
+		for {
+			if !first {
+				k = mac(k, v, []byte{0x00})
+				v = mac(k, v)
+			}
+			first = false
+
+			var t []byte
+			for len(t) < rlen {
+				v = mac(k, v)
+				t = append(t, v...)
+			}
+			candidate := bitsToInt(t, qlen)
+			utils.Zeroize(t)
+			if candidate.Sign() > 0 && candidate.Cmp(q) < 0 {
+				return candidate
+			}
+		}
+	}
+}
+
+// bitsToInt interprets the leftmost qlen bits of b as an integer.
+func bitsToInt(b []byte, qlen int) *big.Int {
+	v := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - qlen; excess > 0 {
+		v.Rsh(v, uint(excess))
+	}
+	return v
+}
+
+// intToOctets encodes v as rlen big-endian bytes.
+func intToOctets(v *big.Int, rlen int) []byte {
+	return v.FillBytes(make([]byte, rlen))
+}
+
+// bitsToOctets reduces a digest mod q and encodes it like intToOctets.
+func bitsToOctets(b []byte, q *big.Int) []byte {
+	z := bitsToInt(b, q.BitLen())
+	if z.Cmp(q) >= 0 {
+		z.Sub(z, q)
+	}
+	return intToOctets(z, (q.BitLen()+7)/8)
+}
@@ -0,0 +1,74 @@
+package signing
+
+import (
+	"encoding/asn1"
+	"math/big"
+)
+
+// derSignature is the ASN.1 Ecdsa-Sig-Value structure (RFC 3279).
+type derSignature struct {
+	R, S *big.Int
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                     DER                                    */
+/* -------------------------------------------------------------------------- */
+// returns the signature as a DER encoded SEQUENCE { r, s }, the encoding used
+// by X.509, Java's SHA256withECDSA and crypto/ecdsa.
+func (s *Signature) DER() ([]byte, error) {
+	if s == nil || s.R == nil || s.S == nil {
+		return nil, ErrInvalidSignature
+	}
+	return asn1.Marshal(derSignature{R: s.R, S: s.S})
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  ParseDER                                  */
+/* -------------------------------------------------------------------------- */
+// parses a DER encoded signature. The values of r and s are checked by
+// Verify.
+func ParseDER(der []byte) (*Signature, error) {
+	var sig derSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil || len(rest) > 0 || sig.R == nil || sig.S == nil {
+		return nil, ErrInvalidSignature
+	}
+	return &Signature{R: sig.R, S: sig.S}, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                     Raw                                    */
+/* -------------------------------------------------------------------------- */
+// returns r || s, each padded to the byte length of the curve order, the
+// encoding used by JWS and PKCS#11.
+func (h *signingHandler) Raw(s *Signature) ([]byte, error) {
+	size := h.scalarSize()
+	if s == nil || s.R == nil || s.S == nil || s.R.Sign() < 0 || s.S.Sign() < 0 ||
+		s.R.BitLen() > 8*size || s.S.BitLen() > 8*size {
+		return nil, ErrInvalidSignature
+	}
+	raw := make([]byte, 2*size)
+	s.R.FillBytes(raw[:size])
+	s.S.FillBytes(raw[size:])
+	return raw, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  ParseRaw                                  */
+/* -------------------------------------------------------------------------- */
+// parses an r || s signature made on the handler's curve.
+func (h *signingHandler) ParseRaw(raw []byte) (*Signature, error) {
+	size := h.scalarSize()
+	if len(raw) != 2*size {
+		return nil, ErrInvalidSignature
+	}
+	return &Signature{
+		R: new(big.Int).SetBytes(raw[:size]),
+		S: new(big.Int).SetBytes(raw[size:]),
+	}, nil
+}
+
+// scalarSize returns the byte length of the curve order.
+func (h *signingHandler) scalarSize() int {
+	return (h.Curve.Q.BitLen() + 7) / 8
+}
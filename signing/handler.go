@@ -0,0 +1,48 @@
+// Package signing implements ECDSA over utils.Curve with deterministic
+// nonces (RFC 6979), so HIP and HIU can authenticate the ECDH key material
+// they exchange with long-term signing keys.
+//
+// Signing keys are ordinary key pairs from keypairgen. Use a dedicated
+// long-term pair, never the ephemeral ECDH pair being signed. Curves without
+// a crypto/ecdh implementation (BC25519, secp256k1) use math/big arithmetic,
+// which is not constant time.
+package signing
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"hash"
+
+	"github.com/zoop/fidelius-go/utils"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrKeyMaterialExpired is returned by VerifyKeyMaterial for validly
+	// signed key material past its expiry.
+	ErrKeyMaterialExpired = errors.New("key material has expired")
+)
+
+type signingHandler struct {
+	Curve *utils.Curve
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               SigningHandler                               */
+/* -------------------------------------------------------------------------- */
+func Handler(curve *utils.Curve) *signingHandler {
+	handler := &signingHandler{
+		Curve: curve,
+	}
+	return handler
+}
+
+// hash returns the message digest used with the curve: SHA-256 up to 256-bit
+// orders and SHA-384 above.
+func (h *signingHandler) hash() func() hash.Hash {
+	if h.Curve.Q.BitLen() > 256 {
+		return sha512.New384
+	}
+	return sha256.New
+}
@@ -0,0 +1,107 @@
+package signing
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+// keyMaterialDomain separates key material signatures from signatures over
+// any other data made with the same key.
+const keyMaterialDomain = "fidelius-go/key-material/v2"
+
+/* -------------------------------------------------------------------------- */
+/*                             KeyMaterialMessage                             */
+/* -------------------------------------------------------------------------- */
+// returns the bytes signed for key material: a domain string, the curve name,
+// the public key as an uncompressed point, the raw nonce and the expiry as
+// 8-byte big-endian Unix seconds, each prefixed with its 2-byte length. The
+// public key may be given uncompressed or as X.509; both sign the same
+// message.
+func (h *signingHandler) KeyMaterialMessage(publicKey, nonce string, expiresAt time.Time) ([]byte, error) {
+	point, err := utils.DecodeBase64ToPublicKey(publicKey, h.Curve)
+	if err != nil {
+		return nil, errors.Wrap(err, "[KeyMaterialMessage][utils.DecodeBase64ToPublicKey]")
+	}
+	pointBytes := utils.EncodePublicKey(h.Curve, point.X, point.Y)
+	nonceBytes, err := utils.DecodeBase64(nonce)
+	if err != nil {
+		return nil, errors.Wrap(err, "[KeyMaterialMessage][utils.DecodeBase64]")
+	}
+
+	expiry := binary.BigEndian.AppendUint64(nil, uint64(expiresAt.Unix()))
+
+	var message []byte
+	for _, field := range [][]byte{[]byte(keyMaterialDomain), []byte(h.Curve.Name), pointBytes, nonceBytes, expiry} {
+		if len(field) > 0xffff {
+			return nil, utils.WithKind(utils.ErrInvalidInput, errors.New("key material field is too long"))
+		}
+		message = binary.BigEndian.AppendUint16(message, uint16(len(field)))
+		message = append(message, field...)
+	}
+	return message, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               SignKeyMaterial                              */
+/* -------------------------------------------------------------------------- */
+// signs the public key and nonce of keyMaterial with the long-term signing
+// key signingPrivateKey, valid for ttl from now. The result carries no
+// private key and can be sent to the peer as is.
+func (h *signingHandler) SignKeyMaterial(signingPrivateKey string, keyMaterial *keypairgen.KeyMaterial, ttl time.Duration) (*SignedKeyMaterial, error) {
+	if ttl <= 0 {
+		return nil, utils.WithKind(utils.ErrInvalidInput, errors.New("key material ttl must be positive"))
+	}
+	// whole seconds, so the signed expiry survives the JSON round trip
+	expiresAt := time.Unix(time.Now().Add(ttl).Unix(), 0).UTC()
+	message, err := h.KeyMaterialMessage(keyMaterial.PublicKey, keyMaterial.Nonce, expiresAt)
+	if err != nil {
+		return nil, errors.Wrap(err, "[SignKeyMaterial][KeyMaterialMessage]")
+	}
+	signature, err := h.Sign(signingPrivateKey, message)
+	if err != nil {
+		return nil, errors.Wrap(err, "[SignKeyMaterial][Sign]")
+	}
+	der, err := signature.DER()
+	if err != nil {
+		return nil, errors.Wrap(err, "[SignKeyMaterial][DER]")
+	}
+	return &SignedKeyMaterial{
+		PublicKey: keyMaterial.PublicKey,
+		Nonce:     keyMaterial.Nonce,
+		ExpiresAt: expiresAt,
+		Signature: utils.EncodeBase64(der),
+	}, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              VerifyKeyMaterial                             */
+/* -------------------------------------------------------------------------- */
+// checks signed key material against the peer's pinned signing public key
+// and rejects it with ErrKeyMaterialExpired once its expiry has passed, so
+// old material can't be replayed. Only use the public key and nonce after it
+// returns nil.
+func (h *signingHandler) VerifyKeyMaterial(signingPublicKey string, signed *SignedKeyMaterial) error {
+	message, err := h.KeyMaterialMessage(signed.PublicKey, signed.Nonce, signed.ExpiresAt)
+	if err != nil {
+		return errors.Wrap(err, "[VerifyKeyMaterial][KeyMaterialMessage]")
+	}
+	der, err := utils.DecodeBase64(signed.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	signature, err := ParseDER(der)
+	if err != nil {
+		return err
+	}
+	if err := h.Verify(signingPublicKey, message, signature); err != nil {
+		return err
+	}
+	if !time.Now().Before(signed.ExpiresAt) {
+		return ErrKeyMaterialExpired
+	}
+	return nil
+}
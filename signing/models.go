@@ -0,0 +1,23 @@
+package signing
+
+import (
+	"math/big"
+	"time"
+)
+
+// Signature is an ECDSA signature (r, s).
+type Signature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// SignedKeyMaterial is the public half of a key pair with a signature by the
+// sender's long-term signing key, ready to be sent to the peer.
+type SignedKeyMaterial struct {
+	PublicKey string `json:"publicKey"`
+	Nonce     string `json:"nonce"`
+	// ExpiresAt is signed along with the key material; VerifyKeyMaterial
+	// rejects the material from then on.
+	ExpiresAt time.Time `json:"expiresAt"`
+	Signature string    `json:"signature"`
+}
@@ -0,0 +1,261 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/keypairgen"
+	"github.com/zoop/fidelius-go/utils"
+)
+
+func hexInt(t *testing.T, s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	assert.True(t, ok)
+	return v
+}
+
+// signingKey returns the base64 private and public keys for scalar x.
+func signingKey(t *testing.T, curve *utils.Curve, x *big.Int) (string, string) {
+	px, py, err := utils.GeneratePublicKey(curve, x)
+	assert.NoError(t, err)
+	return utils.EncodePrivateKeyToBase64(x), utils.EncodeCurvePublicKeyToBase64(curve, px, py)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         Tests for RFC 6979 vectors                         */
+/* -------------------------------------------------------------------------- */
+func TestSignRFC6979(t *testing.T) {
+	// RFC 6979 A.2.5, P-256 with SHA-256
+	P256, err := utils.GetP256Curve()
+	assert.NoError(t, err)
+	handler := Handler(P256)
+	privateKey, publicKey := signingKey(t, P256,
+		hexInt(t, "C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721"))
+
+	for _, tc := range []struct{ message, r, s string }{
+		{
+			message: "sample",
+			r:       "EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+			s:       "F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+		},
+		{
+			message: "test",
+			r:       "F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+			s:       "019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083",
+		},
+	} {
+		signature, err := handler.Sign(privateKey, []byte(tc.message))
+		assert.NoError(t, err)
+		assert.Equal(t, hexInt(t, tc.r), signature.R, tc.message)
+		assert.Equal(t, hexInt(t, tc.s), signature.S, tc.message)
+		assert.NoError(t, handler.Verify(publicKey, []byte(tc.message), signature))
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for Sign and Verify                         */
+/* -------------------------------------------------------------------------- */
+func TestSignVerify(t *testing.T) {
+	for _, get := range []func() (*utils.Curve, error){
+		utils.GetBC25519Curve, utils.GetP256Curve, utils.GetP384Curve, utils.GetSecp256k1Curve,
+	} {
+		curve, err := get()
+		assert.NoError(t, err)
+		keyMaterial, err := keypairgen.Handler(curve).Generate()
+		assert.NoError(t, err)
+		handler := Handler(curve)
+		message := []byte("health information")
+
+		signature, err := handler.Sign(keyMaterial.PrivateKey, message)
+		assert.NoError(t, err, curve.Name)
+		again, err := handler.Sign(keyMaterial.PrivateKey, message)
+		assert.NoError(t, err)
+		assert.Equal(t, signature, again, "signatures should be deterministic")
+
+		assert.NoError(t, handler.Verify(keyMaterial.PublicKey, message, signature), curve.Name)
+		assert.NoError(t, handler.Verify(keyMaterial.X509PublicKey, message, signature), curve.Name)
+		assert.ErrorIs(t, handler.Verify(keyMaterial.PublicKey, []byte("tampered"), signature), ErrInvalidSignature)
+
+		tampered := &Signature{R: signature.R, S: new(big.Int).Add(signature.S, big.NewInt(1))}
+		assert.ErrorIs(t, handler.Verify(keyMaterial.PublicKey, message, tampered), ErrInvalidSignature)
+		assert.ErrorIs(t, handler.Verify(keyMaterial.PublicKey, message, &Signature{R: big.NewInt(0), S: signature.S}), ErrInvalidSignature)
+		assert.ErrorIs(t, handler.Verify(keyMaterial.PublicKey, message, &Signature{R: signature.R, S: curve.Q}), ErrInvalidSignature)
+		assert.ErrorIs(t, handler.Verify(keyMaterial.PublicKey, message, nil), ErrInvalidSignature)
+
+		other, err := keypairgen.Handler(curve).Generate()
+		assert.NoError(t, err)
+		assert.ErrorIs(t, handler.Verify(other.PublicKey, message, signature), ErrInvalidSignature)
+	}
+}
+
+func TestSignInvalidKey(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	for _, key := range []*big.Int{big.NewInt(0), BC25519.Q} {
+		_, err := handler.Sign(utils.EncodePrivateKeyToBase64(key), []byte("message"))
+		assert.Equal(t, utils.ErrInvalidKey, utils.KindOf(err))
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         Tests for crypto/ecdsa interop                     */
+/* -------------------------------------------------------------------------- */
+func TestStdlibInterop(t *testing.T) {
+	for _, tc := range []struct {
+		get   func() (*utils.Curve, error)
+		curve elliptic.Curve
+	}{
+		{utils.GetP256Curve, elliptic.P256()},
+		{utils.GetP384Curve, elliptic.P384()},
+	} {
+		curve, err := tc.get()
+		assert.NoError(t, err)
+		handler := Handler(curve)
+		keyMaterial, err := keypairgen.Handler(curve).Generate()
+		assert.NoError(t, err)
+		point, err := utils.DecodeBase64ToPublicKey(keyMaterial.PublicKey, curve)
+		assert.NoError(t, err)
+		stdKey := &ecdsa.PublicKey{Curve: tc.curve, X: point.X, Y: point.Y}
+
+		message := []byte("interop")
+		digest := handler.hash()()
+		digest.Write(message)
+		hashed := digest.Sum(nil)
+
+		signature, err := handler.Sign(keyMaterial.PrivateKey, message)
+		assert.NoError(t, err)
+		der, err := signature.DER()
+		assert.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(stdKey, hashed, der), curve.Name)
+
+		// and the other way round
+		x, err := utils.DecodeBase64ToPrivateKey(keyMaterial.PrivateKey)
+		assert.NoError(t, err)
+		stdPrivate := &ecdsa.PrivateKey{PublicKey: *stdKey, D: x}
+		stdDER, err := ecdsa.SignASN1(rand.Reader, stdPrivate, hashed)
+		assert.NoError(t, err)
+		parsed, err := ParseDER(stdDER)
+		assert.NoError(t, err)
+		assert.NoError(t, handler.VerifyDigest(keyMaterial.PublicKey, hashed, parsed), curve.Name)
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Tests for encodings                             */
+/* -------------------------------------------------------------------------- */
+func TestEncodings(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	signature := &Signature{R: big.NewInt(1), S: new(big.Int).Sub(BC25519.Q, big.NewInt(1))}
+
+	der, err := signature.DER()
+	assert.NoError(t, err)
+	parsed, err := ParseDER(der)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, parsed)
+	_, err = ParseDER(append(der, 0))
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+
+	raw, err := handler.Raw(signature)
+	assert.NoError(t, err)
+	assert.Len(t, raw, 64)
+	assert.Equal(t, byte(1), raw[31])
+	parsed, err = handler.ParseRaw(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, signature, parsed)
+	_, err = handler.ParseRaw(raw[1:])
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Tests for KeyMaterial                            */
+/* -------------------------------------------------------------------------- */
+func TestKeyMaterial(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	generator := keypairgen.Handler(BC25519)
+	signingKeys, err := generator.Generate()
+	assert.NoError(t, err)
+	keyMaterial, err := generator.Generate()
+	assert.NoError(t, err)
+
+	signed, err := handler.SignKeyMaterial(signingKeys.PrivateKey, keyMaterial, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, keyMaterial.PublicKey, signed.PublicKey)
+	assert.Equal(t, keyMaterial.Nonce, signed.Nonce)
+	assert.NoError(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, signed))
+
+	// the X.509 form of the same key signs the same message
+	x509 := *signed
+	x509.PublicKey = keyMaterial.X509PublicKey
+	assert.NoError(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, &x509))
+
+	// a swapped public key, a swapped nonce or the wrong signer is rejected
+	attacker, err := generator.Generate()
+	assert.NoError(t, err)
+	swapped := *signed
+	swapped.PublicKey = attacker.PublicKey
+	assert.ErrorIs(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, &swapped), ErrInvalidSignature)
+	swapped = *signed
+	swapped.Nonce = attacker.Nonce
+	assert.ErrorIs(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, &swapped), ErrInvalidSignature)
+	assert.ErrorIs(t, handler.VerifyKeyMaterial(attacker.PublicKey, signed), ErrInvalidSignature)
+
+	resigned, err := handler.SignKeyMaterial(attacker.PrivateKey, attacker, time.Hour)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, resigned), ErrInvalidSignature)
+
+	// the expiry is signed: extending it breaks the signature
+	extended := *signed
+	extended.ExpiresAt = signed.ExpiresAt.Add(24 * time.Hour)
+	assert.ErrorIs(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, &extended), ErrInvalidSignature)
+
+	// and it survives a JSON round trip
+	data, err := json.Marshal(signed)
+	assert.NoError(t, err)
+	var decoded SignedKeyMaterial
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.NoError(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, &decoded))
+
+	_, err = handler.SignKeyMaterial(signingKeys.PrivateKey, keyMaterial, 0)
+	assert.Equal(t, utils.ErrInvalidInput, utils.KindOf(err))
+}
+
+func TestKeyMaterialExpiry(t *testing.T) {
+	BC25519, err := utils.GetBC25519Curve()
+	assert.NoError(t, err)
+	handler := Handler(BC25519)
+	generator := keypairgen.Handler(BC25519)
+	signingKeys, err := generator.Generate()
+	assert.NoError(t, err)
+	keyMaterial, err := generator.Generate()
+	assert.NoError(t, err)
+
+	// validly signed material whose expiry has passed, or that has none, is
+	// rejected
+	past := time.Unix(time.Now().Add(-time.Second).Unix(), 0).UTC()
+	for _, expiresAt := range []time.Time{past, {}} {
+		message, err := handler.KeyMaterialMessage(keyMaterial.PublicKey, keyMaterial.Nonce, expiresAt)
+		assert.NoError(t, err)
+		signature, err := handler.Sign(signingKeys.PrivateKey, message)
+		assert.NoError(t, err)
+		der, err := signature.DER()
+		assert.NoError(t, err)
+		expired := &SignedKeyMaterial{
+			PublicKey: keyMaterial.PublicKey,
+			Nonce:     keyMaterial.Nonce,
+			ExpiresAt: expiresAt,
+			Signature: utils.EncodeBase64(der),
+		}
+		assert.ErrorIs(t, handler.VerifyKeyMaterial(signingKeys.PublicKey, expired), ErrKeyMaterialExpired)
+	}
+}
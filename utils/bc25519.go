@@ -2,7 +2,18 @@ package utils
 
 import "math/big"
 
+var bc25519 curveSingleton
+
+/* -------------------------------------------------------------------------- */
+/*                               GetBC25519Curve                              */
+/* -------------------------------------------------------------------------- */
+// returns Curve25519 in short Weierstrass form as used by Bouncy Castle and
+// ABDM. Every call returns the same instance.
 func GetBC25519Curve() (*Curve, error) {
+	return bc25519.get(newBC25519Curve)
+}
+
+func newBC25519Curve() (*Curve, error) {
 	// Define BC25519 curve parameters
 	p, _ := new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
 	a, _ := new(big.Int).SetString("2aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa984914a144", 16)
@@ -10,7 +21,7 @@ func GetBC25519Curve() (*Curve, error) {
 	q, _ := new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
 	gx, _ := new(big.Int).SetString("2aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaad245a", 16)
 	gy, _ := new(big.Int).SetString("20ae19a1b8a086b4e01edd2c7748d14c923d4d7e6d7c61b229e9c5a27eced3d9", 16)
-	oid := []byte{0x01, 0x03, 0x06, 0x01, 0x04, 0x01, 0x97, 0x55, 0x05, 0x01}
+	oid := []byte{0x01, 0x03, 0x06, 0x01, 0x04, 0x01, 0x97, 0x55, 0x01, 0x05, 0x01} // 1.3.6.1.4.1.3029.1.5.1
 	curve, err := NewCurve("BC25519", p, a, b, q, gx, gy, oid)
 	return curve, err
 }
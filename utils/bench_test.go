@@ -0,0 +1,106 @@
+package utils
+
+import "testing"
+
+/* -------------------------------------------------------------------------- */
+/*                         Benchmarks for key agreement                       */
+/* -------------------------------------------------------------------------- */
+func BenchmarkGeneratePrivateKey(b *testing.B) {
+	BC25519, err := GetBC25519Curve()
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := GeneratePrivateKey(BC25519); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGeneratePublicKey(b *testing.B) {
+	BC25519, err := GetBC25519Curve()
+	if err != nil {
+		b.Fatal(err)
+	}
+	privateKey, err := GeneratePrivateKey(BC25519)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := GeneratePublicKey(BC25519, privateKey); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkComputeSharedSecret(b *testing.B) {
+	BC25519, err := GetBC25519Curve()
+	if err != nil {
+		b.Fatal(err)
+	}
+	privateKey, err := GeneratePrivateKey(BC25519)
+	if err != nil {
+		b.Fatal(err)
+	}
+	x, y, err := GeneratePublicKey(BC25519, privateKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+	encodedPrivateKey := EncodePrivateKeyToBase64(privateKey)
+	encodedPublicKey := EncodePublicKeyToBase64(x, y)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ComputeSharedSecret(encodedPrivateKey, encodedPublicKey, BC25519); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkX25519SharedSecret(b *testing.B) {
+	BC25519, err := GetBC25519Curve()
+	if err != nil {
+		b.Fatal(err)
+	}
+	privateKey, err := GeneratePrivateKey(BC25519)
+	if err != nil {
+		b.Fatal(err)
+	}
+	x, y, err := GeneratePublicKey(BC25519, privateKey)
+	if err != nil {
+		b.Fatal(err)
+	}
+	key, err := NewX25519ECDH(BC25519, EncodePrivateKeyToBase64(privateKey))
+	if err != nil {
+		b.Fatal(err)
+	}
+	peer := &Point{X: x, Y: y, Curve: BC25519}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sharedSecret, err := key.SharedSecret(peer)
+		if err != nil {
+			b.Fatal(err)
+		}
+		sharedSecret.Destroy()
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             Benchmarks for HKDF                            */
+/* -------------------------------------------------------------------------- */
+func BenchmarkSha256HKDF(b *testing.B) {
+	salt := make([]byte, 20)
+	sharedSecret := NewSecret(make([]byte, 32))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		key, err := Sha256HKDF(salt, sharedSecret, 32)
+		if err != nil {
+			b.Fatal(err)
+		}
+		key.Destroy()
+	}
+}
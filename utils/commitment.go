@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+)
+
+// CommitmentSize is the length of a key commitment tag.
+const CommitmentSize = 32
+
+// commitmentInfo separates the commitment tag from the AES key, which is
+// derived from the same shared secret and salt with no info.
+const commitmentInfo = "fidelius-go/key-commitment/v1"
+
+/* -------------------------------------------------------------------------- */
+/*                                KeyCommitment                               */
+/* -------------------------------------------------------------------------- */
+// returns a tag that commits to the AES key derived from sharedSecret and
+// salt. AES-GCM alone is not key-committing: a ciphertext can be crafted to
+// decrypt under two different keys. HKDF-SHA256 is collision resistant, so
+// finding two shared secrets with the same tag is infeasible.
+func KeyCommitment(salt []byte, sharedSecret *Secret) ([]byte, error) {
+	keys, err := DeriveKeys(sha256.New, sharedSecret, salt, []byte(commitmentInfo), CommitmentSize, 1)
+	if err != nil {
+		return nil, err
+	}
+	defer keys[0].Destroy()
+	return append([]byte(nil), keys[0].Bytes()...), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             VerifyKeyCommitment                            */
+/* -------------------------------------------------------------------------- */
+// reports in constant time whether commitment is the tag for sharedSecret
+// and salt.
+func VerifyKeyCommitment(salt []byte, sharedSecret *Secret, commitment []byte) (bool, error) {
+	expected, err := KeyCommitment(salt, sharedSecret)
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(expected, commitment) == 1, nil
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Compression selects how plaintext is compressed before encryption.
+type Compression byte
+
+const (
+	NoCompression Compression = iota
+	// Deflate is raw DEFLATE (RFC 1951).
+	Deflate
+	// Gzip is gzip (RFC 1952).
+	Gzip
+)
+
+// DefaultMaxDecompressedSize bounds decompressed plaintext unless a handler
+// sets its own limit.
+const DefaultMaxDecompressedSize = 64 << 20
+
+// ErrDecompressedTooLarge is returned when decompressed data exceeds the
+// limit, as a compression bomb would.
+var ErrDecompressedTooLarge = errors.New("decompressed data exceeds the size limit")
+
+func (c Compression) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case Deflate:
+		return "deflate"
+	case Gzip:
+		return "gzip"
+	}
+	return fmt.Sprintf("Compression(%d)", byte(c))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  Compress                                  */
+/* -------------------------------------------------------------------------- */
+// compresses data with c at the default level.
+func Compress(c Compression, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch c {
+	case NoCompression:
+		return data, nil
+	case Deflate:
+		fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		if err != nil {
+			return nil, err
+		}
+		w = fw
+	case Gzip:
+		w = gzip.NewWriter(&buf)
+	default:
+		return nil, WithKind(ErrInvalidInput, fmt.Errorf("unsupported compression %v", c))
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 Decompress                                 */
+/* -------------------------------------------------------------------------- */
+// reverses Compress, reading at most limit bytes of output. Larger output
+// fails with ErrDecompressedTooLarge before more than limit+1 bytes are
+// buffered.
+func Decompress(c Compression, data []byte, limit int64) ([]byte, error) {
+	var r io.ReadCloser
+	switch c {
+	case NoCompression:
+		if int64(len(data)) > limit {
+			return nil, WithKind(ErrInvalidInput, ErrDecompressedTooLarge)
+		}
+		return data, nil
+	case Deflate:
+		r = flate.NewReader(bytes.NewReader(data))
+	case Gzip:
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, WithKind(ErrInvalidInput, err)
+		}
+		gr.Multistream(false)
+		r = gr
+	default:
+		return nil, WithKind(ErrInvalidInput, fmt.Errorf("unsupported compression %v", c))
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, WithKind(ErrInvalidInput, err)
+	}
+	if int64(len(out)) > limit {
+		return nil, WithKind(ErrInvalidInput, ErrDecompressedTooLarge)
+	}
+	return out, nil
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                     Tests for Compress and Decompress                      */
+/* -------------------------------------------------------------------------- */
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"resourceType":"Observation","status":"final"}`), 1000)
+	for _, c := range []Compression{NoCompression, Deflate, Gzip} {
+		compressed, err := Compress(c, data)
+		assert.NoError(t, err, c)
+		if c != NoCompression {
+			assert.Less(t, len(compressed), len(data)/10, c)
+		}
+		decompressed, err := Decompress(c, compressed, int64(len(data)))
+		assert.NoError(t, err, c)
+		assert.Equal(t, data, decompressed)
+
+		// one byte under the real size is a bomb
+		_, err = Decompress(c, compressed, int64(len(data)-1))
+		assert.ErrorIs(t, err, ErrDecompressedTooLarge, c)
+		assert.Equal(t, ErrInvalidInput, KindOf(err))
+	}
+
+	_, err := Compress(Compression(9), data)
+	assert.Equal(t, ErrInvalidInput, KindOf(err))
+	_, err = Decompress(Gzip, []byte("not gzip"), 100)
+	assert.Equal(t, ErrInvalidInput, KindOf(err))
+	_, err = Decompress(Deflate, []byte{0xff, 0xff}, 100)
+	assert.Equal(t, ErrInvalidInput, KindOf(err))
+}
+
+func TestDecompressBomb(t *testing.T) {
+	// 64 MiB of zeros compresses to about 128 KiB
+	bomb, err := Compress(Gzip, make([]byte, 64<<20))
+	assert.NoError(t, err)
+	assert.Less(t, len(bomb), 1<<18)
+	_, err = Decompress(Gzip, bomb, 1<<20)
+	assert.ErrorIs(t, err, ErrDecompressedTooLarge)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         Tests for EnvelopeHeader                           */
+/* -------------------------------------------------------------------------- */
+func TestEnvelopeHeader(t *testing.T) {
+	encoded := EnvelopeHeader{Compression: Gzip}.Marshal()
+	assert.Equal(t, []byte{'F', 'D', 'L', 0x01, 0x02}, encoded)
+	assert.Len(t, encoded, EnvelopeHeaderSize)
+
+	header, rest, ok, err := ParseEnvelopeHeader(append(encoded, 0xaa))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, Gzip, header.Compression)
+	assert.Equal(t, []byte{0xaa}, rest)
+
+	_, rest, ok, err = ParseEnvelopeHeader([]byte("no header"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, []byte("no header"), rest)
+
+	for _, b := range [][]byte{
+		{'F', 'D', 'L', 0x02, 0x00}, // unknown version
+		{'F', 'D', 'L', 0x01, 0x10}, // reserved flag
+		{'F', 'D', 'L', 0x01, 0x07}, // unknown compression
+	} {
+		_, _, ok, err := ParseEnvelopeHeader(b)
+		assert.True(t, ok)
+		assert.Equal(t, ErrInvalidInput, KindOf(err))
+	}
+}
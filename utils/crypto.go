@@ -31,11 +31,31 @@ func GeneratePublicKey(curve *Curve, privateKey *big.Int) (*big.Int, *big.Int, e
 		return nil, nil, errors.New("invalid private key")
 	}
 
+	if curve.ecdh != nil {
+		return ecdhPublicKey(curve, privateKey)
+	}
+
 	// Perform scalar multiplication: P = privateKey * G
 	publicKeyX, publicKeyY := scalarMult(curve.Gx, curve.Gy, privateKey, curve)
 	return publicKeyX, publicKeyY, nil
 }
 
+// ecdhPublicKey computes privateKey * G with the curve's crypto/ecdh
+// implementation.
+func ecdhPublicKey(curve *Curve, privateKey *big.Int) (*big.Int, *big.Int, error) {
+	scalar := privateKey.FillBytes(make([]byte, curve.CoordinateSize()))
+	defer Zeroize(scalar)
+	key, err := curve.ecdh.NewPrivateKey(scalar)
+	if err != nil {
+		return nil, nil, err
+	}
+	point, err := decodeUncompressedPoint(key.PublicKey().Bytes(), curve)
+	if err != nil {
+		return nil, nil, err
+	}
+	return point.X, point.Y, nil
+}
+
 // scalarMult performs scalar multiplication (k * P) on the curve using double-and-add method.
 func scalarMult(x, y, k *big.Int, curve *Curve) (*big.Int, *big.Int) {
 	resultX, resultY := big.NewInt(0), big.NewInt(0) // Initialize to the point at infinity
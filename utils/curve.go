@@ -1,7 +1,7 @@
 package utils
 
 import (
-	"errors"
+	"crypto/ecdh"
 	"math/big"
 )
 
@@ -15,12 +15,17 @@ type Curve struct {
 	Gx   *big.Int // The x-coordinate of the base point
 	Gy   *big.Int // The y-coordinate of the base point
 	OID  []byte   // The object identifier of the curve
-}
 
-// oidLookup is a map to look up curves by their OID.
-var oidLookup = map[string]*Curve{}
+	// ecdh, when set, is the crypto/ecdh implementation of the curve. Key
+	// agreement and public key generation use it instead of the generic
+	// arithmetic.
+	ecdh ecdh.Curve
+}
 
-// NewCurve initializes a new Curve instance.
+// NewCurve initializes a new Curve instance. The parameters aren't checked;
+// call Validate before using a curve from configuration or an X.509 key. The
+// curve isn't registered either: Register validates it and makes it
+// available to LookupByName and LookupByOID.
 func NewCurve(name string, p, a, b, q, gx, gy *big.Int, oid []byte) (*Curve, error) {
 	curve := &Curve{
 		Name: name,
@@ -32,19 +37,14 @@ func NewCurve(name string, p, a, b, q, gx, gy *big.Int, oid []byte) (*Curve, err
 		Gy:   gy,
 		OID:  oid,
 	}
-	if len(oid) > 0 {
-		oidLookup[string(oid)] = curve
-	}
 	return curve, nil
 }
 
-// GetCurveByOID retrieves a curve by its object identifier.
+// GetCurveByOID retrieves a registered curve by its object identifier.
+//
+// Deprecated: use LookupByOID.
 func GetCurveByOID(oid []byte) (*Curve, error) {
-	curve, exists := oidLookup[string(oid)]
-	if !exists {
-		return nil, errors.New("curve not found")
-	}
-	return curve, nil
+	return LookupByOID(oid)
 }
 
 // IsPointOnCurve checks if a point (x, y) lies on the curve.
@@ -67,6 +67,11 @@ func (c *Curve) Evaluate(x *big.Int) *big.Int {
 	return result
 }
 
+// CoordinateSize returns the encoded length of a coordinate in bytes.
+func (c *Curve) CoordinateSize() int {
+	return (c.P.BitLen() + 7) / 8
+}
+
 // BasePoint returns the base point (Gx, Gy) of the curve.
 func (c *Curve) BasePoint() (*big.Int, *big.Int) {
 	return c.Gx, c.Gy
@@ -0,0 +1,161 @@
+package utils
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var curveConstructors = map[string]func() (*Curve, error){
+	"BC25519":   GetBC25519Curve,
+	"P-256":     GetP256Curve,
+	"P-384":     GetP384Curve,
+	"secp256k1": GetSecp256k1Curve,
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for built-in curves                         */
+/* -------------------------------------------------------------------------- */
+func TestBuiltinCurves(t *testing.T) {
+	sizes := map[string]int{"BC25519": 32, "P-256": 32, "P-384": 48, "secp256k1": 32}
+	oids := map[string]asn1.ObjectIdentifier{
+		"BC25519":   {1, 3, 6, 1, 4, 1, 3029, 1, 5, 1},
+		"P-256":     {1, 2, 840, 10045, 3, 1, 7},
+		"P-384":     {1, 3, 132, 0, 34},
+		"secp256k1": {1, 3, 132, 0, 10},
+	}
+	for name, constructor := range curveConstructors {
+		t.Run(name, func(t *testing.T) {
+			curve, err := constructor()
+			assert.NoError(t, err)
+			assert.Equal(t, name, curve.Name)
+			assert.True(t, curve.IsPointOnCurve(curve.Gx, curve.Gy))
+			assert.Equal(t, sizes[name], curve.CoordinateSize())
+			oid, err := objectIdentifier(curve.OID)
+			assert.NoError(t, err)
+			assert.Equal(t, oids[name], oid)
+
+			/* --------------------- Key pair and encoding round trip -------------------- */
+			privateKey, err := GeneratePrivateKey(curve)
+			assert.NoError(t, err)
+			x, y, err := GeneratePublicKey(curve, privateKey)
+			assert.NoError(t, err)
+			for _, encode := range []func(*Curve, *big.Int, *big.Int) (string, error){
+				func(c *Curve, x, y *big.Int) (string, error) { return EncodeCurvePublicKeyToBase64(c, x, y), nil },
+				EncodeCurveX509PublicKeyToBase64,
+			} {
+				encoded, err := encode(curve, x, y)
+				assert.NoError(t, err)
+				point, err := DecodeBase64ToPublicKey(encoded, curve)
+				assert.NoError(t, err)
+				assert.Equal(t, 0, point.X.Cmp(x))
+				assert.Equal(t, 0, point.Y.Cmp(y))
+			}
+		})
+	}
+}
+
+func TestSecp256k1KnownPoint(t *testing.T) {
+	curve, err := GetSecp256k1Curve()
+	assert.NoError(t, err)
+	x, y, err := GeneratePublicKey(curve, big.NewInt(2))
+	assert.NoError(t, err)
+	assert.Equal(t, "c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5", x.Text(16))
+	assert.Equal(t, "1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a", y.Text(16))
+}
+
+/* -------------------------------------------------------------------------- */
+/*             Differential tests for the crypto/ecdh-backed curves           */
+/* -------------------------------------------------------------------------- */
+func TestECDHMatchesGenericArithmetic(t *testing.T) {
+	for _, constructor := range []func() (*Curve, error){GetP256Curve, GetP384Curve} {
+		curve, err := constructor()
+		assert.NoError(t, err)
+		// The same curve without crypto/ecdh uses the generic arithmetic
+		generic := *curve
+		generic.ecdh = nil
+
+		privateKey, err := GeneratePrivateKey(curve)
+		assert.NoError(t, err)
+		peerPrivateKey, err := GeneratePrivateKey(curve)
+		assert.NoError(t, err)
+
+		x, y, err := GeneratePublicKey(curve, privateKey)
+		assert.NoError(t, err)
+		gx, gy, err := GeneratePublicKey(&generic, privateKey)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, x.Cmp(gx))
+		assert.Equal(t, 0, y.Cmp(gy))
+
+		px, py, err := GeneratePublicKey(curve, peerPrivateKey)
+		assert.NoError(t, err)
+		encodedPrivateKey := EncodePrivateKeyToBase64(privateKey)
+
+		key, err := NewPrivateKeyECDH(curve, encodedPrivateKey)
+		assert.NoError(t, err)
+		want, err := key.SharedSecret(&Point{X: px, Y: py, Curve: curve})
+		assert.NoError(t, err)
+		genericKey, err := NewPrivateKeyECDH(&generic, encodedPrivateKey)
+		assert.NoError(t, err)
+		got, err := genericKey.SharedSecret(&Point{X: px, Y: py, Curve: &generic})
+		assert.NoError(t, err)
+		assert.Equal(t, want.Bytes(), got.Bytes())
+		assert.Len(t, got.Bytes(), curve.CoordinateSize())
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                      Tests for X.509 interoperability                      */
+/* -------------------------------------------------------------------------- */
+func TestX509Interop(t *testing.T) {
+	for _, c := range []struct {
+		constructor func() (*Curve, error)
+		ecdh        ecdh.Curve
+	}{{GetP256Curve, ecdh.P256()}, {GetP384Curve, ecdh.P384()}} {
+		curve, err := c.constructor()
+		assert.NoError(t, err)
+
+		// Our encoding parses with crypto/x509
+		privateKey, err := GeneratePrivateKey(curve)
+		assert.NoError(t, err)
+		x, y, err := GeneratePublicKey(curve, privateKey)
+		assert.NoError(t, err)
+		encoded, err := EncodeCurveX509PublicKeyToBase64(curve, x, y)
+		assert.NoError(t, err)
+		der, err := base64.StdEncoding.DecodeString(encoded)
+		assert.NoError(t, err)
+		parsed, err := x509.ParsePKIXPublicKey(der)
+		assert.NoError(t, err)
+		ecdsaKey, ok := parsed.(*ecdsa.PublicKey)
+		if assert.True(t, ok) {
+			assert.Equal(t, 0, ecdsaKey.X.Cmp(x))
+			assert.Equal(t, 0, ecdsaKey.Y.Cmp(y))
+		}
+
+		// crypto/x509's encoding parses with ours
+		native, err := c.ecdh.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		der, err = x509.MarshalPKIXPublicKey(native.PublicKey())
+		assert.NoError(t, err)
+		point, err := ParseX509PublicKey(der, curve)
+		assert.NoError(t, err)
+		assert.Equal(t, native.PublicKey().Bytes(), encodeUncompressedPoint(point.X, point.Y, curve.CoordinateSize()))
+	}
+
+	// A key for one curve is rejected for another
+	p256, err := GetP256Curve()
+	assert.NoError(t, err)
+	secp256k1, err := GetSecp256k1Curve()
+	assert.NoError(t, err)
+	encoded, err := EncodeCurveX509PublicKeyToBase64(p256, p256.Gx, p256.Gy)
+	assert.NoError(t, err)
+	_, err = DecodeBase64ToPublicKey(encoded, secp256k1)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// ECDHKey is a private key that can take part in ECDH without handing its
+// scalar to the caller. Implementations may keep the key in another process,
+// a KMS or an HSM.
+type ECDHKey interface {
+	// PublicKey returns the public point matching the private key.
+	PublicKey() (*Point, error)
+	// SharedSecret returns the big-endian x-coordinate of privateKey * peerPublicKey,
+	// left-padded to the curve's coordinate size as Bouncy Castle's ECDH
+	// agreement does.
+	// The caller owns the returned Secret and should Destroy it after use.
+	SharedSecret(peerPublicKey *Point) (*Secret, error)
+}
+
+type privateKeyECDH struct {
+	curve      *Curve
+	privateKey *big.Int
+
+	once      sync.Once
+	publicKey *Point
+	err       error
+}
+
+/* -------------------------------------------------------------------------- */
+/*                              NewPrivateKeyECDH                             */
+/* -------------------------------------------------------------------------- */
+// wraps a base64 encoded private key held in memory. This is the default used
+// by the encryption and decryption handlers.
+func NewPrivateKeyECDH(curve *Curve, encodedPrivateKey string) (*privateKeyECDH, error) {
+	if curve == nil {
+		return nil, errors.New("curve cannot be nil")
+	}
+	privateKey, err := DecodeBase64ToPrivateKey(encodedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	if privateKey.Sign() <= 0 || privateKey.Cmp(curve.Q) >= 0 {
+		ZeroizeBigInt(privateKey)
+		return nil, WithKind(ErrInvalidKey, errors.New("invalid private key"))
+	}
+	return &privateKeyECDH{curve: curve, privateKey: privateKey}, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               GenerateECDHKey                              */
+/* -------------------------------------------------------------------------- */
+// generates a random private key on curve, e.g. for an ephemeral key pair.
+// The scalar is never encoded, so Destroy wipes the only copy.
+func GenerateECDHKey(curve *Curve) (*privateKeyECDH, error) {
+	privateKey, err := GeneratePrivateKey(curve)
+	if err != nil {
+		return nil, err
+	}
+	return &privateKeyECDH{curve: curve, privateKey: privateKey}, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 NewECDHKey                                 */
+/* -------------------------------------------------------------------------- */
+// wraps a base64 encoded private key held in memory with NewX25519ECDH if
+// x25519 is set, or NewPrivateKeyECDH otherwise. It also returns the key's
+// Destroy method, for callers that only see the ECDHKey.
+func NewECDHKey(curve *Curve, encodedPrivateKey string, x25519 bool) (ECDHKey, func(), error) {
+	if x25519 {
+		key, err := NewX25519ECDH(curve, encodedPrivateKey)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, key.Destroy, nil
+	}
+	key, err := NewPrivateKeyECDH(curve, encodedPrivateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, key.Destroy, nil
+}
+
+// Destroy wipes the private scalar. The key can't be used afterwards.
+func (k *privateKeyECDH) Destroy() {
+	ZeroizeBigInt(k.privateKey)
+}
+
+func (k *privateKeyECDH) PublicKey() (*Point, error) {
+	k.once.Do(func() {
+		x, y, err := GeneratePublicKey(k.curve, k.privateKey)
+		if err != nil {
+			k.err = err
+			return
+		}
+		k.publicKey = &Point{X: x, Y: y, Curve: k.curve}
+	})
+	return k.publicKey, k.err
+}
+
+func (k *privateKeyECDH) SharedSecret(peerPublicKey *Point) (*Secret, error) {
+	if peerPublicKey == nil || !sameCurve(peerPublicKey.Curve, k.curve) {
+		return nil, WithKind(ErrInvalidKey, errors.New("peer public key is not on the key's curve"))
+	}
+	if !k.curve.IsPointOnCurve(peerPublicKey.X, peerPublicKey.Y) {
+		return nil, WithKind(ErrInvalidKey, errors.New("peer public key is not on the curve"))
+	}
+	if k.privateKey.Sign() <= 0 {
+		return nil, errors.New("private key has been destroyed")
+	}
+	if k.curve.ecdh != nil {
+		return ecdhSharedSecret(k.curve, k.privateKey, peerPublicKey)
+	}
+	sharedSecretPoint := peerPublicKey.ScalarMul(k.privateKey)
+	if sharedSecretPoint == IdentityPoint {
+		return nil, WithKind(ErrInvalidKey, errors.New("shared secret is the point at infinity"))
+	}
+	sharedSecret := NewSecret(sharedSecretPoint.X.FillBytes(make([]byte, k.curve.CoordinateSize())))
+	if sharedSecretPoint != peerPublicKey {
+		ZeroizeBigInt(sharedSecretPoint.X)
+		ZeroizeBigInt(sharedSecretPoint.Y)
+	}
+	return sharedSecret, nil
+}
+
+// ecdhSharedSecret computes the shared secret with the curve's crypto/ecdh
+// implementation. crypto/ecdh keeps its own copy of the scalar, which can't
+// be wiped.
+func ecdhSharedSecret(curve *Curve, privateKey *big.Int, peerPublicKey *Point) (*Secret, error) {
+	scalar := privateKey.FillBytes(make([]byte, curve.CoordinateSize()))
+	defer Zeroize(scalar)
+	key, err := curve.ecdh.NewPrivateKey(scalar)
+	if err != nil {
+		return nil, WithKind(ErrInvalidKey, err)
+	}
+	peer, err := curve.ecdh.NewPublicKey(encodeUncompressedPoint(peerPublicKey.X, peerPublicKey.Y, curve.CoordinateSize()))
+	if err != nil {
+		return nil, WithKind(ErrInvalidKey, err)
+	}
+	sharedSecret, err := key.ECDH(peer)
+	if err != nil {
+		return nil, WithKind(ErrInvalidKey, err)
+	}
+	return NewSecret(sharedSecret), nil
+}
+
+// sameCurve reports whether two curve values describe the same curve.
+func sameCurve(c1, c2 *Curve) bool {
+	if c1 == c2 {
+		return true
+	}
+	return c1 != nil && c2 != nil && c1.P.Cmp(c2.P) == 0 && c1.A.Cmp(c2.A) == 0 && c1.B.Cmp(c2.B) == 0
+}
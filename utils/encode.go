@@ -7,6 +7,11 @@ import (
 	"math/big"
 )
 
+// coordinateSize is the encoded length of a BC25519 coordinate. Coordinates
+// are left-padded with zeros so encoded keys always have a fixed length; see
+// Curve.CoordinateSize for other curves.
+const coordinateSize = 32
+
 /* -------------------------------------------------------------------------- */
 /*                          EncodePrivateKeyToBase64                          */
 /* -------------------------------------------------------------------------- */
@@ -18,19 +23,43 @@ func EncodePrivateKeyToBase64(key *big.Int) string {
 /* -------------------------------------------------------------------------- */
 /*                           EncodePublicKeyToBase64                          */
 /* -------------------------------------------------------------------------- */
-// encodes a public key to base64 format in uncompressed form.
+// encodes a BC25519 public key to base64 format in uncompressed form. Use
+// EncodeCurvePublicKeyToBase64 for other curves.
 func EncodePublicKeyToBase64(x, y *big.Int) string {
+	return base64.StdEncoding.EncodeToString(encodeUncompressedPoint(x, y, coordinateSize))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                        EncodeCurvePublicKeyToBase64                        */
+/* -------------------------------------------------------------------------- */
+// encodes a public key on curve to base64 format in uncompressed form.
+func EncodeCurvePublicKeyToBase64(curve *Curve, x, y *big.Int) string {
+	return base64.StdEncoding.EncodeToString(encodeUncompressedPoint(x, y, curve.CoordinateSize()))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               EncodePublicKey                              */
+/* -------------------------------------------------------------------------- */
+// returns a public key on curve in uncompressed form, 0x04 || X || Y.
+func EncodePublicKey(curve *Curve, x, y *big.Int) []byte {
+	return encodeUncompressedPoint(x, y, curve.CoordinateSize())
+}
+
+// encodeUncompressedPoint returns 0x04 || X || Y with size-byte coordinates.
+func encodeUncompressedPoint(x, y *big.Int, size int) []byte {
 	var buf bytes.Buffer
 	buf.WriteByte(0x04) // Uncompressed point indicator
-	buf.Write(x.Bytes())
-	buf.Write(y.Bytes())
-	return base64.StdEncoding.EncodeToString(buf.Bytes())
+	buf.Write(x.FillBytes(make([]byte, size)))
+	buf.Write(y.FillBytes(make([]byte, size)))
+	return buf.Bytes()
 }
 
 /* -------------------------------------------------------------------------- */
 /*                         EncodeX509PublicKeyToBase64                        */
 /* -------------------------------------------------------------------------- */
-// encodes a public key to X.509 base64 format.
+// encodes a BC25519 public key to X.509 base64 format the way Bouncy Castle
+// does, with explicit curve parameters. Use EncodeCurveX509PublicKeyToBase64
+// for other curves.
 func EncodeX509PublicKeyToBase64(x, y *big.Int) (string, error) {
 	// Prefix from Bouncy Castle X.509 public key encoding
 	fixedPrefixB64 := "MIIBMTCB6gYHKoZIzj0CATCB3gIBATArBgcqhkjOPQEBAiB/////////////////////////////////////////7TBEBCAqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqYSRShRAQge0Je0Je0Je0Je0Je0Je0Je0Je0Je0Je0JgtenHcQyGQEQQQqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq0kWiCuGaG4oIa04B7dLHdI0UySPU1+bXxhsinpxaJ+ztPZAiAQAAAAAAAAAAAAAAAAAAAAFN753qL3nNZYEmMaXPXT7QIBCANCAAQ="
@@ -41,12 +70,29 @@ func EncodeX509PublicKeyToBase64(x, y *big.Int) (string, error) {
 
 	var buf bytes.Buffer
 	buf.Write(fixedPrefix)
-	buf.Write(x.Bytes())
-	buf.Write(y.Bytes())
+	buf.Write(x.FillBytes(make([]byte, coordinateSize)))
+	buf.Write(y.FillBytes(make([]byte, coordinateSize)))
 
 	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
 }
 
+/* -------------------------------------------------------------------------- */
+/*                      EncodeCurveX509PublicKeyToBase64                      */
+/* -------------------------------------------------------------------------- */
+// encodes a public key on curve to X.509 base64 format. BC25519 keys use the
+// Bouncy Castle encoding; other curves are identified by their named-curve
+// OID.
+func EncodeCurveX509PublicKeyToBase64(curve *Curve, x, y *big.Int) (string, error) {
+	if isBC25519(curve) {
+		return EncodeX509PublicKeyToBase64(x, y)
+	}
+	der, err := marshalX509PublicKey(curve, encodeUncompressedPoint(x, y, curve.CoordinateSize()))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
 /* -------------------------------------------------------------------------- */
 /*                             GenerateBase64Nonce                            */
 /* -------------------------------------------------------------------------- */
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// envelopeMagic starts every envelope header.
+var envelopeMagic = []byte("FDL")
+
+const (
+	// envelopeHeaderVersion is the only header version so far.
+	envelopeHeaderVersion = 0x01
+	// EnvelopeHeaderSize is the encoded length of an EnvelopeHeader.
+	EnvelopeHeaderSize = 5
+	// compressionMask selects the compression bits of the flags byte; the
+	// other bits are reserved and must be zero.
+	compressionMask = 0x0f
+)
+
+// EnvelopeHeader describes processing applied to a ciphertext beyond plain
+// Fidelius, such as compression. It is encoded as
+//
+//	"FDL" || version (1) || flags (1)
+//
+// in front of the ciphertext and authenticated as AES-GCM additional data.
+// Ciphertexts without a header are plain Fidelius.
+type EnvelopeHeader struct {
+	Compression Compression
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Marshal                                  */
+/* -------------------------------------------------------------------------- */
+// returns the encoded header.
+func (h EnvelopeHeader) Marshal() []byte {
+	header := append([]byte(nil), envelopeMagic...)
+	return append(header, envelopeHeaderVersion, byte(h.Compression)&compressionMask)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             ParseEnvelopeHeader                            */
+/* -------------------------------------------------------------------------- */
+// parses the header at the start of data and returns it with the rest of
+// data. ok is false when data doesn't start with the magic; a plain Fidelius
+// ciphertext may still start with it by chance, so callers should fall back
+// to treating data as headerless if authentication with the header fails.
+func ParseEnvelopeHeader(data []byte) (header EnvelopeHeader, rest []byte, ok bool, err error) {
+	if len(data) < EnvelopeHeaderSize || !bytes.HasPrefix(data, envelopeMagic) {
+		return EnvelopeHeader{}, data, false, nil
+	}
+	version, flags := data[len(envelopeMagic)], data[len(envelopeMagic)+1]
+	if version != envelopeHeaderVersion {
+		return EnvelopeHeader{}, data, true, WithKind(ErrInvalidInput, fmt.Errorf("unsupported envelope header version %d", version))
+	}
+	if flags&^compressionMask != 0 {
+		return EnvelopeHeader{}, data, true, WithKind(ErrInvalidInput, fmt.Errorf("reserved envelope header flags %#x", flags))
+	}
+	header.Compression = Compression(flags & compressionMask)
+	if header.Compression > Gzip {
+		return EnvelopeHeader{}, data, true, WithKind(ErrInvalidInput, fmt.Errorf("unsupported compression %v", header.Compression))
+	}
+	return header, data[EnvelopeHeaderSize:], true, nil
+}
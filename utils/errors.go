@@ -0,0 +1,86 @@
+package utils
+
+import "errors"
+
+// Error kinds returned by this library. Errors are tagged with a kind, so
+// callers can use errors.Is(err, utils.ErrInvalidKey) while the message
+// still describes the underlying cause.
+var (
+	// ErrInvalidInput marks malformed requests: bad base64, nonces of the
+	// wrong length or truncated ciphertext.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrInvalidKey marks private or public keys that can't be decoded or
+	// don't lie on the curve.
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrDecryptionFailed marks ciphertext that fails authentication, either
+	// because it was tampered with or because the wrong keys or nonces were used.
+	ErrDecryptionFailed = errors.New("decryption failed")
+)
+
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string   { return e.err.Error() }
+func (e *kindError) Unwrap() []error { return []error{e.kind, e.err} }
+
+/* -------------------------------------------------------------------------- */
+/*                                  WithKind                                  */
+/* -------------------------------------------------------------------------- */
+// tags err with one of the error kinds above without changing its message.
+func WithKind(kind, err error) error {
+	if err == nil || errors.Is(err, kind) {
+		return err
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  KindOf                                    */
+/* -------------------------------------------------------------------------- */
+// returns the kind err was tagged with, or nil if it has none.
+func KindOf(err error) error {
+	for _, kind := range []error{ErrInvalidInput, ErrInvalidKey, ErrDecryptionFailed} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 ErrorCode                                  */
+/* -------------------------------------------------------------------------- */
+// returns a stable machine-readable code for err's kind, for use in logs and
+// wire formats. Errors without a kind map to "INTERNAL".
+func ErrorCode(err error) string {
+	switch KindOf(err) {
+	case ErrInvalidInput:
+		return "INVALID_INPUT"
+	case ErrInvalidKey:
+		return "INVALID_KEY"
+	case ErrDecryptionFailed:
+		return "DECRYPTION_FAILED"
+	default:
+		return "INTERNAL"
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                KindFromCode                                */
+/* -------------------------------------------------------------------------- */
+// returns the error kind for a code produced by ErrorCode, or nil for
+// "INTERNAL" and unknown codes.
+func KindFromCode(code string) error {
+	switch code {
+	case "INVALID_INPUT":
+		return ErrInvalidInput
+	case "INVALID_KEY":
+		return ErrInvalidKey
+	case "DECRYPTION_FAILED":
+		return ErrDecryptionFailed
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/zoop/fidelius-go/internal/testvectors"
+)
+
+// addPublicKeySeeds seeds f with the vector public keys and inputs that used
+// to panic or sit on a length boundary.
+func addPublicKeySeeds(f *testing.F) {
+	vectors, err := testvectors.Load()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, v := range vectors {
+		f.Add(v.SenderPublicKey)
+		if v.RequesterPublicKey != "" {
+			f.Add(v.RequesterPublicKey)
+		}
+	}
+	for _, n := range []int{0, 1, 32, 63, 64, 65, 66, 91} {
+		f.Add(base64.StdEncoding.EncodeToString(make([]byte, n)))
+	}
+	f.Add("not base64")
+}
+
+/* -------------------------------------------------------------------------- */
+/*                       Fuzz DecodeBase64ToPublicKey                         */
+/* -------------------------------------------------------------------------- */
+func FuzzDecodeBase64ToPublicKey(f *testing.F) {
+	BC25519, err := GetBC25519Curve()
+	if err != nil {
+		f.Fatal(err)
+	}
+	addPublicKeySeeds(f)
+	f.Fuzz(func(t *testing.T, encodedKey string) {
+		point, err := DecodeBase64ToPublicKey(encodedKey, BC25519)
+		if err != nil {
+			return
+		}
+		if !BC25519.IsPointOnCurve(point.X, point.Y) {
+			t.Fatalf("decoded point is not on the curve")
+		}
+		// A decoded key must survive a round trip
+		again, err := DecodeBase64ToPublicKey(EncodePublicKeyToBase64(point.X, point.Y), BC25519)
+		if err != nil || again.X.Cmp(point.X) != 0 || again.Y.Cmp(point.Y) != 0 {
+			t.Fatalf("public key round trip failed: %v", err)
+		}
+	})
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           Fuzz ParseX509PublicKey                          */
+/* -------------------------------------------------------------------------- */
+func FuzzParseX509PublicKey(f *testing.F) {
+	BC25519, err := GetBC25519Curve()
+	if err != nil {
+		f.Fatal(err)
+	}
+	vectors, err := testvectors.Load()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, v := range vectors {
+		der, _ := base64.StdEncoding.DecodeString(v.SenderPublicKey)
+		f.Add(der)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0x30, 0x00})
+	f.Add([]byte{0x30, 0x82, 0xff, 0xff})
+	f.Fuzz(func(t *testing.T, der []byte) {
+		point, err := ParseX509PublicKey(der, BC25519)
+		if err == nil && !BC25519.IsPointOnCurve(point.X, point.Y) {
+			t.Fatalf("parsed point is not on the curve")
+		}
+	})
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Fuzz private key decoding                         */
+/* -------------------------------------------------------------------------- */
+func FuzzNewPrivateKeyECDH(f *testing.F) {
+	BC25519, err := GetBC25519Curve()
+	if err != nil {
+		f.Fatal(err)
+	}
+	vectors, err := testvectors.Load()
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, v := range vectors {
+		f.Add(v.RequesterPrivateKey)
+	}
+	f.Add("")
+	f.Add("AA==")
+	f.Add(base64.StdEncoding.EncodeToString(BC25519.Q.Bytes()))
+	f.Add("not base64")
+	f.Fuzz(func(t *testing.T, encodedKey string) {
+		if _, err := DecodeBase64ToPrivateKey(encodedKey); err != nil {
+			return
+		}
+		key, err := NewPrivateKeyECDH(BC25519, encodedKey)
+		if err != nil {
+			return
+		}
+		defer key.Destroy()
+		// Every accepted key must have a public key
+		if _, err := key.PublicKey(); err != nil {
+			t.Fatalf("accepted private key has no public key: %v", err)
+		}
+	})
+}
@@ -2,10 +2,6 @@ package utils
 
 import (
 	"crypto/rand"
-	"crypto/sha256"
-	"io"
-
-	"golang.org/x/crypto/hkdf"
 )
 
 /* -------------------------------------------------------------------------- */
@@ -19,16 +15,3 @@ func GenerateRandomNonce(size int) string {
 	}
 	return EncodeBase64(nonce)
 }
-
-/* -------------------------------------------------------------------------- */
-/*                                  DeriveKey                                 */
-/* -------------------------------------------------------------------------- */
-func DeriveKey(salt []byte, sharedSecret string, keyLength int) []byte {
-	hkdf := hkdf.New(sha256.New, []byte(sharedSecret), salt, nil)
-	key := make([]byte, keyLength)
-	_, err := io.ReadFull(hkdf, key)
-	if err != nil {
-		panic("failed to derive key using HKDF")
-	}
-	return key
-}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"math/big"
+)
+
+var p256, p384 curveSingleton
+
+/* -------------------------------------------------------------------------- */
+/*                                 GetP256Curve                               */
+/* -------------------------------------------------------------------------- */
+// returns NIST P-256 (secp256r1). Key agreement uses crypto/ecdh. Every
+// call returns the same instance.
+func GetP256Curve() (*Curve, error) {
+	return p256.get(newP256Curve)
+}
+
+func newP256Curve() (*Curve, error) {
+	oid := []byte{0x01, 0x02, 0x86, 0x48, 0xce, 0x3d, 0x03, 0x01, 0x07} // 1.2.840.10045.3.1.7
+	return newNISTCurve("P-256", elliptic.P256().Params(), ecdh.P256(), oid)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 GetP384Curve                               */
+/* -------------------------------------------------------------------------- */
+// returns NIST P-384 (secp384r1). Key agreement uses crypto/ecdh. Every
+// call returns the same instance.
+func GetP384Curve() (*Curve, error) {
+	return p384.get(newP384Curve)
+}
+
+func newP384Curve() (*Curve, error) {
+	oid := []byte{0x01, 0x03, 0x81, 0x04, 0x00, 0x22} // 1.3.132.0.34
+	return newNISTCurve("P-384", elliptic.P384().Params(), ecdh.P384(), oid)
+}
+
+// newNISTCurve builds a Curve from crypto/elliptic parameters. NIST curves
+// all have a = -3.
+func newNISTCurve(name string, params *elliptic.CurveParams, ec ecdh.Curve, oid []byte) (*Curve, error) {
+	a := new(big.Int).Sub(params.P, big.NewInt(3))
+	curve, err := NewCurve(name,
+		new(big.Int).Set(params.P), a, new(big.Int).Set(params.B), new(big.Int).Set(params.N),
+		new(big.Int).Set(params.Gx), new(big.Int).Set(params.Gy), oid)
+	if err != nil {
+		return nil, err
+	}
+	curve.ecdh = ec
+	return curve, nil
+}
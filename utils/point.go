@@ -27,6 +27,13 @@ func (e *CurveMismatchError) Error() string {
 
 // NewPoint creates a new elliptic curve point.
 func NewPoint(x, y *big.Int, curve *Curve) (*Point, error) {
+	if curve == nil {
+		return nil, fmt.Errorf("curve cannot be nil")
+	}
+	// Reject non-canonical coordinates, which would alias points mod p
+	if x.Sign() < 0 || y.Sign() < 0 || x.Cmp(curve.P) >= 0 || y.Cmp(curve.P) >= 0 {
+		return nil, fmt.Errorf("coordinates are out of range for curve <%s>", curve.Name)
+	}
 	// Ensure the point lies on the curve
 	if !curve.IsPointOnCurve(x, y) {
 		return nil, fmt.Errorf("coordinates are not on curve <%s>\n\tx=%x\ny=%x", curve.Name, x, y)
@@ -93,11 +100,12 @@ func (p *Point) Negate() *Point {
 func (p *Point) ScalarMul(scalar *big.Int) *Point {
 	result := IdentityPoint
 	current := p
-	scalarBits := scalar.Text(2) // Binary representation of scalar
 
-	for _, bit := range scalarBits {
+	// Walk the bits directly; formatting the scalar would leave a copy of
+	// the private key in an immutable string.
+	for i := scalar.BitLen() - 1; i >= 0; i-- {
 		result, _ = result.Add(result)
-		if bit == '1' {
+		if scalar.Bit(i) == 1 {
 			result, _ = result.Add(current)
 		}
 	}
@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrCurveNotFound is returned when no registered curve matches a lookup.
+	ErrCurveNotFound = errors.New("curve not found")
+	// ErrCurveExists is returned when registering a curve whose name or OID
+	// is already taken.
+	ErrCurveExists = errors.New("curve already registered")
+)
+
+// registry holds the registered curves. The built-in curves are added the
+// first time it's used.
+var registry = struct {
+	sync.RWMutex
+	byName map[string]*Curve
+	byOID  map[string]*Curve
+}{
+	byName: map[string]*Curve{},
+	byOID:  map[string]*Curve{},
+}
+
+var registerBuiltinsOnce sync.Once
+
+// builtinCurves are the constructors of the curves registered by default.
+var builtinCurves = []func() (*Curve, error){
+	GetBC25519Curve, GetP256Curve, GetP384Curve, GetSecp256k1Curve,
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  Register                                  */
+/* -------------------------------------------------------------------------- */
+// validates curve and adds it to the registry. It fails with ErrCurveExists
+// if a curve with the same name or OID is already registered. Registered
+// curves are shared and must not be modified.
+func Register(curve *Curve) error {
+	registerBuiltinsOnce.Do(registerBuiltins)
+	return register(curve)
+}
+
+func register(curve *Curve) error {
+	if curve == nil || curve.Name == "" {
+		return errors.New("curve must have a name")
+	}
+	if err := curve.Validate(); err != nil {
+		return fmt.Errorf("curve %q: %w", curve.Name, err)
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, exists := registry.byName[curve.Name]; exists {
+		return fmt.Errorf("%w: name %q", ErrCurveExists, curve.Name)
+	}
+	if len(curve.OID) > 0 {
+		if other, exists := registry.byOID[string(curve.OID)]; exists {
+			return fmt.Errorf("%w: OID of %q is used by %q", ErrCurveExists, curve.Name, other.Name)
+		}
+		registry.byOID[string(curve.OID)] = curve
+	}
+	registry.byName[curve.Name] = curve
+	return nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                LookupByName                                */
+/* -------------------------------------------------------------------------- */
+// returns the registered curve with the given name, e.g. "BC25519" or "P-256".
+func LookupByName(name string) (*Curve, error) {
+	registerBuiltinsOnce.Do(registerBuiltins)
+	registry.RLock()
+	defer registry.RUnlock()
+	curve, exists := registry.byName[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrCurveNotFound, name)
+	}
+	return curve, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                 LookupByOID                                */
+/* -------------------------------------------------------------------------- */
+// returns the registered curve with the given OID, in the byte form of
+// Curve.OID.
+func LookupByOID(oid []byte) (*Curve, error) {
+	registerBuiltinsOnce.Do(registerBuiltins)
+	registry.RLock()
+	defer registry.RUnlock()
+	curve, exists := registry.byOID[string(oid)]
+	if !exists {
+		return nil, ErrCurveNotFound
+	}
+	return curve, nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Curves                                   */
+/* -------------------------------------------------------------------------- */
+// returns all registered curves sorted by name.
+func Curves() []*Curve {
+	registerBuiltinsOnce.Do(registerBuiltins)
+	registry.RLock()
+	curves := make([]*Curve, 0, len(registry.byName))
+	for _, curve := range registry.byName {
+		curves = append(curves, curve)
+	}
+	registry.RUnlock()
+	sort.Slice(curves, func(i, j int) bool { return curves[i].Name < curves[j].Name })
+	return curves
+}
+
+// registerBuiltins registers the built-in curves.
+func registerBuiltins() {
+	for _, constructor := range builtinCurves {
+		curve, err := constructor()
+		if err != nil {
+			panic("utils: invalid built-in curve: " + err.Error())
+		}
+		if err := register(curve); err != nil {
+			panic("utils: " + err.Error())
+		}
+	}
+}
+
+// curveSingleton builds a built-in curve once and hands out the same
+// instance afterwards.
+type curveSingleton struct {
+	once  sync.Once
+	curve *Curve
+	err   error
+}
+
+func (s *curveSingleton) get(constructor func() (*Curve, error)) (*Curve, error) {
+	s.once.Do(func() {
+		s.curve, s.err = constructor()
+	})
+	return s.curve, s.err
+}
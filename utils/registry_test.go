@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testCurve returns a copy of P-256 under a new name and OID.
+func testCurve(t *testing.T, name string, oid []byte) *Curve {
+	p256, err := GetP256Curve()
+	assert.NoError(t, err)
+	curve, err := NewCurve(name, p256.P, p256.A, p256.B, p256.Q, p256.Gx, p256.Gy, oid)
+	assert.NoError(t, err)
+	return curve
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Tests for the registry                          */
+/* -------------------------------------------------------------------------- */
+func TestRegistryBuiltins(t *testing.T) {
+	bc25519Curve, err := GetBC25519Curve()
+	assert.NoError(t, err)
+	again, err := GetBC25519Curve()
+	assert.NoError(t, err)
+	assert.Same(t, bc25519Curve, again)
+
+	for _, name := range []string{"BC25519", "P-256", "P-384", "secp256k1"} {
+		byName, err := LookupByName(name)
+		assert.NoError(t, err)
+		byOID, err := LookupByOID(byName.OID)
+		assert.NoError(t, err)
+		assert.Same(t, byName, byOID)
+	}
+	byName, err := LookupByName("BC25519")
+	assert.NoError(t, err)
+	assert.Same(t, bc25519Curve, byName)
+
+	var names []string
+	for _, curve := range Curves() {
+		names = append(names, curve.Name)
+	}
+	assert.Subset(t, names, []string{"BC25519", "P-256", "P-384", "secp256k1"})
+	assert.IsNonDecreasing(t, names)
+
+	_, err = LookupByName("P-521")
+	assert.ErrorIs(t, err, ErrCurveNotFound)
+	_, err = LookupByOID([]byte{0x01, 0x02})
+	assert.ErrorIs(t, err, ErrCurveNotFound)
+}
+
+func TestRegisterDuplicates(t *testing.T) {
+	curve := testCurve(t, "test-duplicates", []byte{0x01, 0x03, 0x06, 0x01, 0x04, 0x01, 0x7f, 0x01})
+	assert.NoError(t, Register(curve))
+
+	found, err := LookupByName("test-duplicates")
+	assert.NoError(t, err)
+	assert.Same(t, curve, found)
+
+	// Same name, and same OID under another name
+	assert.ErrorIs(t, Register(testCurve(t, "test-duplicates", nil)), ErrCurveExists)
+	assert.ErrorIs(t, Register(testCurve(t, "test-duplicates-2", curve.OID)), ErrCurveExists)
+	_, err = LookupByName("test-duplicates-2")
+	assert.ErrorIs(t, err, ErrCurveNotFound)
+
+	bc25519Curve, err := GetBC25519Curve()
+	assert.NoError(t, err)
+	assert.ErrorIs(t, Register(bc25519Curve), ErrCurveExists)
+}
+
+// TestRegistryConcurrency is meant to run with -race.
+func TestRegistryConcurrency(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			curve := testCurve(t, fmt.Sprintf("test-concurrent-%d", i), []byte{0x01, 0x03, 0x06, 0x01, 0x04, 0x01, 0x7e, byte(i)})
+			assert.NoError(t, Register(curve))
+			for j := 0; j < 50; j++ {
+				_, err := GetBC25519Curve()
+				assert.NoError(t, err)
+				_, err = LookupByName("P-256")
+				assert.NoError(t, err)
+				_, err = LookupByOID(curve.OID)
+				assert.NoError(t, err)
+				Curves()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, len(Curves()), 4+16)
+}
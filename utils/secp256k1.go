@@ -0,0 +1,26 @@
+package utils
+
+import "math/big"
+
+var secp256k1 curveSingleton
+
+/* -------------------------------------------------------------------------- */
+/*                              GetSecp256k1Curve                             */
+/* -------------------------------------------------------------------------- */
+// returns secp256k1 from SEC 2. It has no crypto/ecdh implementation, so it
+// uses the generic arithmetic. Every call returns the same instance.
+func GetSecp256k1Curve() (*Curve, error) {
+	return secp256k1.get(newSecp256k1Curve)
+}
+
+func newSecp256k1Curve() (*Curve, error) {
+	p, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	a := big.NewInt(0)
+	b := big.NewInt(7)
+	q, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	gx, _ := new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	gy, _ := new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+	oid := []byte{0x01, 0x03, 0x81, 0x04, 0x00, 0x0a} // 1.3.132.0.10
+	curve, err := NewCurve("secp256k1", p, a, b, q, gx, gy, oid)
+	return curve, err
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"math/big"
+
+	"github.com/zoop/fidelius-go/internal/secretwatch"
+)
+
+// Secret holds sensitive bytes such as shared secrets and derived keys.
+// Call Destroy as soon as the value is no longer needed to wipe the buffer.
+type Secret struct {
+	b []byte
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  NewSecret                                 */
+/* -------------------------------------------------------------------------- */
+// takes ownership of b; the caller must not keep using the slice.
+func NewSecret(b []byte) *Secret {
+	secretwatch.Notify(b)
+	return &Secret{b: b}
+}
+
+// Bytes returns the underlying buffer. It is only valid until Destroy.
+func (s *Secret) Bytes() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.b
+}
+
+// Len returns the length of the secret in bytes.
+func (s *Secret) Len() int {
+	return len(s.Bytes())
+}
+
+// Destroy overwrites the secret with zeros and releases it.
+func (s *Secret) Destroy() {
+	if s == nil {
+		return
+	}
+	Zeroize(s.b)
+	s.b = nil
+}
+
+// Destroyed reports whether Destroy has been called.
+func (s *Secret) Destroyed() bool {
+	return s == nil || s.b == nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                   Zeroize                                  */
+/* -------------------------------------------------------------------------- */
+// overwrites b with zeros.
+func Zeroize(b []byte) {
+	clear(b)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                               ZeroizeBigInt                                */
+/* -------------------------------------------------------------------------- */
+// overwrites the words backing n and sets it to zero. Copies made by earlier
+// big.Int arithmetic can't be reached and are left to the garbage collector.
+func ZeroizeBigInt(n *big.Int) {
+	if n == nil {
+		return
+	}
+	clear(n.Bits())
+	n.SetInt64(0)
+}
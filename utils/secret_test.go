@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                              Tests for Secret                              */
+/* -------------------------------------------------------------------------- */
+func TestSecretDestroy(t *testing.T) {
+	buf := []byte{1, 2, 3, 4}
+	secret := NewSecret(buf)
+	assert.Equal(t, 4, secret.Len())
+	assert.False(t, secret.Destroyed())
+
+	secret.Destroy()
+	assert.True(t, secret.Destroyed())
+	assert.Equal(t, []byte{0, 0, 0, 0}, buf)
+	assert.Nil(t, secret.Bytes())
+	secret.Destroy()
+}
+
+func TestZeroizeBigInt(t *testing.T) {
+	n, _ := new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+	words := n.Bits()
+	ZeroizeBigInt(n)
+	assert.Equal(t, 0, n.Sign())
+	for _, word := range words[:cap(words)] {
+		assert.Zero(t, word)
+	}
+}
+
+func TestPrivateKeyECDHDestroy(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+	privateKey, err := GeneratePrivateKey(BC25519)
+	assert.NoError(t, err)
+	key, err := NewPrivateKeyECDH(BC25519, EncodePrivateKeyToBase64(privateKey))
+	assert.NoError(t, err)
+	publicKey, err := key.PublicKey()
+	assert.NoError(t, err)
+
+	sharedSecret, err := key.SharedSecret(publicKey)
+	assert.NoError(t, err)
+	assert.NotZero(t, sharedSecret.Len())
+	sharedSecret.Destroy()
+
+	key.Destroy()
+	_, err = key.SharedSecret(publicKey)
+	assert.Error(t, err)
+}
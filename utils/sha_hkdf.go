@@ -3,7 +3,6 @@ package utils
 import (
 	"crypto/hmac"
 	"crypto/sha256"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"hash"
@@ -12,75 +11,87 @@ import (
 /* -------------------------------------------------------------------------- */
 /*                                    HKDF                                    */
 /* -------------------------------------------------------------------------- */
-// HKDF derives one key from a master secret using the HMAC-based KDF defined in RFC 5869.
-func HKDF(master []byte, keyLen int, salt []byte, hashmod func() hash.Hash, numKeys int, context []byte) ([]byte, error) {
+// HKDF derives numKeys keys of keyLen bytes each from a master secret using
+// the HMAC-based KDF defined in RFC 5869. The info string provides domain
+// separation: keys derived with different info values are independent.
+// Any hash constructor may be used, e.g. sha256.New, sha512.New384 or
+// sha512.New.
+func HKDF(master []byte, keyLen int, salt []byte, hashmod func() hash.Hash, numKeys int, info []byte) ([][]byte, error) {
+	if hashmod == nil {
+		return nil, errors.New("hash function cannot be nil")
+	}
+	if keyLen <= 0 || numKeys <= 0 {
+		return nil, errors.New("key length and number of keys must be positive")
+	}
+
 	// Output length must be within bounds
+	hashLen := hashmod().Size()
 	outputLen := keyLen * numKeys
-	if outputLen > (255 * hashmod().Size()) {
-		return nil, errors.New("Too much secret data to derive")
+	if outputLen > (255 * hashLen) {
+		return nil, errors.New("too much secret data to derive")
 	}
 
 	// Step 1: Extract
 	if len(salt) == 0 {
-		salt = make([]byte, hashmod().Size())
+		salt = make([]byte, hashLen)
 	}
 	hmacInstance := hmac.New(hashmod, salt)
 	hmacInstance.Write(master)
 	prk := hmacInstance.Sum(nil)
+	defer Zeroize(prk)
 
 	// Step 2: Expand
+	derivedOutput := make([]byte, 0, outputLen+hashLen)
+	defer Zeroize(derivedOutput[:cap(derivedOutput)])
+	hmacInstance = hmac.New(hashmod, prk)
 	var t []byte
-	n := 1
-	tLen := 0
-	var derivedOutput []byte
-
-	for tLen < outputLen {
-		hmacInstance = hmac.New(hashmod, prk)
+	for n := 1; len(derivedOutput) < outputLen; n++ {
+		hmacInstance.Reset()
 		hmacInstance.Write(t)
-		hmacInstance.Write(context)
+		hmacInstance.Write(info)
 		hmacInstance.Write([]byte{byte(n)})
-		t = hmacInstance.Sum(nil)
-		derivedOutput = append(derivedOutput, t...)
-		tLen += hashmod().Size()
-		n++
+		t = hmacInstance.Sum(derivedOutput[len(derivedOutput):len(derivedOutput)])
+		derivedOutput = derivedOutput[:len(derivedOutput)+hashLen]
 	}
 
-	// If numKeys is 1, return a single key
-	if numKeys == 1 {
-		return derivedOutput[:keyLen], nil
+	// Split the output into keys
+	keys := make([][]byte, numKeys)
+	for i := range keys {
+		keys[i] = append([]byte(nil), derivedOutput[i*keyLen:(i+1)*keyLen]...)
 	}
-
-	// Otherwise, return multiple keys
-	var kol [][]byte
-	for i := 0; i < numKeys; i++ {
-		start := i * keyLen
-		end := start + keyLen
-		if end > len(derivedOutput) {
-			end = len(derivedOutput)
-		}
-		kol = append(kol, derivedOutput[start:end])
-	}
-
-	// In case numKeys is greater than 1, return the array of keys
-	return kol[0], nil
+	return keys, nil
 }
 
 /* -------------------------------------------------------------------------- */
-/*                                 sha256Hkdf                                 */
+/*                                 DeriveKeys                                 */
 /* -------------------------------------------------------------------------- */
-// sha256Hkdf is the function that decodes the shared secret from base64 and performs HKDF.
-func Sha256HKDF(salt []byte, sharedSecret string, keyLengthInBytes int) ([]byte, error) {
-	// Decode the shared secret from Base64
-	sharedSecretBytes, err := base64.StdEncoding.DecodeString(sharedSecret)
+// DeriveKeys runs HKDF over a shared secret and returns the keys as Secrets,
+// which the caller should Destroy after use.
+func DeriveKeys(hashmod func() hash.Hash, sharedSecret *Secret, salt, info []byte, keyLength, numKeys int) ([]*Secret, error) {
+	if sharedSecret.Destroyed() {
+		return nil, errors.New("shared secret has been destroyed")
+	}
+	keys, err := HKDF(sharedSecret.Bytes(), keyLength, salt, hashmod, numKeys, info)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding shared secret: %v %s", err, sharedSecret)
+		return nil, fmt.Errorf("error deriving keys: %v", err)
+	}
+	secrets := make([]*Secret, len(keys))
+	for i, key := range keys {
+		secrets[i] = NewSecret(key)
 	}
+	return secrets, nil
+}
 
-	// Perform HKDF using SHA-256
-	encryptionKey, err := HKDF(sharedSecretBytes, keyLengthInBytes, salt, sha256.New, 1, nil)
+/* -------------------------------------------------------------------------- */
+/*                                 sha256Hkdf                                 */
+/* -------------------------------------------------------------------------- */
+// sha256Hkdf derives an AES key from the raw shared secret with HKDF-SHA256
+// and no info, as the Fidelius protocol does.
+// The caller owns the returned Secret and should Destroy it after use.
+func Sha256HKDF(salt []byte, sharedSecret *Secret, keyLengthInBytes int) (*Secret, error) {
+	keys, err := DeriveKeys(sha256.New, sharedSecret, salt, nil, keyLengthInBytes, 1)
 	if err != nil {
-		return nil, fmt.Errorf("error deriving encryption key: %v", err)
+		return nil, err
 	}
-
-	return encryptionKey, nil
+	return keys[0], nil
 }
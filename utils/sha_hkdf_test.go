@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/hkdf"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	assert.NoError(t, err)
+	return b
+}
+
+/* -------------------------------------------------------------------------- */
+/*                     Tests for HKDF (RFC 5869 Appendix A)                   */
+/* -------------------------------------------------------------------------- */
+func TestHKDFRFC5869(t *testing.T) {
+	longIKM := make([]byte, 80)
+	longSalt := make([]byte, 80)
+	longInfo := make([]byte, 80)
+	for i := range longIKM {
+		longIKM[i] = byte(i)
+		longSalt[i] = byte(0x60 + i)
+		longInfo[i] = byte(0xb0 + i)
+	}
+
+	tests := []struct {
+		name    string
+		hashmod func() hash.Hash
+		ikm     []byte
+		salt    []byte
+		info    []byte
+		okm     string
+	}{
+		{
+			name:    "A.1 basic SHA-256",
+			hashmod: sha256.New,
+			ikm:     bytes.Repeat([]byte{0x0b}, 22),
+			salt:    mustHex(t, "000102030405060708090a0b0c"),
+			info:    mustHex(t, "f0f1f2f3f4f5f6f7f8f9"),
+			okm:     "3cb25f25faacd57a90434f64d0362f2a2d2d0a90cf1a5a4c5db02d56ecc4c5bf34007208d5b887185865",
+		},
+		{
+			name:    "A.2 long inputs SHA-256",
+			hashmod: sha256.New,
+			ikm:     longIKM,
+			salt:    longSalt,
+			info:    longInfo,
+			okm: "b11e398dc80327a1c8e7f78c596a49344f012eda2d4efad8a050cc4c19afa97c59045a99cac7827271cb41c65e590e09" +
+				"da3275600c2f09b8367793a9aca3db71cc30c58179ec3e87c14c01d5c1f3434f1d87",
+		},
+		{
+			name:    "A.3 zero-length salt and info SHA-256",
+			hashmod: sha256.New,
+			ikm:     bytes.Repeat([]byte{0x0b}, 22),
+			okm:     "8da4e775a563c18f715f802a063c5a31b8a11f5c5ee1879ec3454e5f3c738d2d9d201395faa4b61a96c8",
+		},
+		{
+			name:    "A.4 basic SHA-1",
+			hashmod: sha1.New,
+			ikm:     bytes.Repeat([]byte{0x0b}, 11),
+			salt:    mustHex(t, "000102030405060708090a0b0c"),
+			info:    mustHex(t, "f0f1f2f3f4f5f6f7f8f9"),
+			okm:     "085a01ea1b10f36933068b56efa5ad81a4f14b822f5b091568a9cdd4f155fda2c22e422478d305f3f896",
+		},
+		{
+			name:    "A.5 long inputs SHA-1",
+			hashmod: sha1.New,
+			ikm:     longIKM,
+			salt:    longSalt,
+			info:    longInfo,
+			okm: "0bd770a74d1160f7c9f12cd5912a06ebff6adcae899d92191fe4305673ba2ffe8fa3f1a4e5ad79f3f334b3b202b2173c" +
+				"486ea37ce3d397ed034c7f9dfeb15c5e927336d0441f4c4300e2cff0d0900b52d3b4",
+		},
+		{
+			name:    "A.6 zero-length salt and info SHA-1",
+			hashmod: sha1.New,
+			ikm:     bytes.Repeat([]byte{0x0b}, 22),
+			okm:     "0ac1af7002b3d761d1e55298da9d0506b9ae52057220a306e07b6b87e8df21d0ea00033de03984d34918",
+		},
+		{
+			name:    "A.7 no salt SHA-1",
+			hashmod: sha1.New,
+			ikm:     bytes.Repeat([]byte{0x0c}, 22),
+			okm:     "2c91117204d745f3500d636a62f64f0ab3bae548aa53d423b0d1f27ebba6f5e5673a081d70cce7acfc48",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			okm := mustHex(t, tt.okm)
+			keys, err := HKDF(tt.ikm, len(okm), tt.salt, tt.hashmod, 1, tt.info)
+			assert.NoError(t, err)
+			assert.Equal(t, okm, keys[0])
+
+			// splitting the same output into two keys yields both halves
+			if len(okm)%2 == 0 {
+				keys, err = HKDF(tt.ikm, len(okm)/2, tt.salt, tt.hashmod, 2, tt.info)
+				assert.NoError(t, err)
+				assert.Equal(t, [][]byte{okm[:len(okm)/2], okm[len(okm)/2:]}, keys)
+			}
+		})
+	}
+}
+
+func TestHKDFHashes(t *testing.T) {
+	master := []byte("shared secret")
+	salt := []byte("salt")
+	info := []byte("fidelius-go aead key")
+	for name, hashmod := range map[string]func() hash.Hash{
+		"SHA-256": sha256.New,
+		"SHA-384": sha512.New384,
+		"SHA-512": sha512.New,
+	} {
+		t.Run(name, func(t *testing.T) {
+			expected := make([]byte, 96)
+			_, err := io.ReadFull(hkdf.New(hashmod, master, salt, info), expected)
+			assert.NoError(t, err)
+
+			keys, err := HKDF(master, 32, salt, hashmod, 3, info)
+			assert.NoError(t, err)
+			assert.Equal(t, [][]byte{expected[:32], expected[32:64], expected[64:]}, keys)
+		})
+	}
+}
+
+func TestHKDFErrors(t *testing.T) {
+	_, err := HKDF([]byte("k"), 32, nil, nil, 1, nil)
+	assert.Error(t, err)
+	_, err = HKDF([]byte("k"), 0, nil, sha256.New, 1, nil)
+	assert.Error(t, err)
+	_, err = HKDF([]byte("k"), 32, nil, sha256.New, 0, nil)
+	assert.Error(t, err)
+	_, err = HKDF([]byte("k"), 255*32+1, nil, sha256.New, 1, nil)
+	assert.Error(t, err)
+}
+
+func TestDeriveKeysDomainSeparation(t *testing.T) {
+	sharedSecret := NewSecret([]byte("shared secret"))
+	aeadKeys, err := DeriveKeys(sha256.New, sharedSecret, []byte("salt"), []byte("aead"), 32, 1)
+	assert.NoError(t, err)
+	macKeys, err := DeriveKeys(sha256.New, sharedSecret, []byte("salt"), []byte("mac"), 32, 1)
+	assert.NoError(t, err)
+	assert.NotEqual(t, aeadKeys[0].Bytes(), macKeys[0].Bytes())
+
+	// the Fidelius derivation is HKDF-SHA256 without info
+	fidelius, err := Sha256HKDF([]byte("salt"), sharedSecret, 32)
+	assert.NoError(t, err)
+	expected, err := HKDF([]byte("shared secret"), 32, []byte("salt"), sha256.New, 1, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected[0], fidelius.Bytes())
+
+	sharedSecret.Destroy()
+	_, err = Sha256HKDF([]byte("salt"), sharedSecret, 32)
+	assert.Error(t, err)
+}
@@ -9,12 +9,35 @@ import (
 /* -------------------------------------------------------------------------- */
 /*                             ComputeSharedSecret                            */
 /* -------------------------------------------------------------------------- */
+// returns the shared secret base64 encoded.
+//
+// Deprecated: the returned string can't be wiped. Use ECDHKey.SharedSecret,
+// which returns a Secret, instead.
 func ComputeSharedSecret(senderPrivateKeyEncoded, requesterPublicKeyEncoded string, curve *Curve) (string, error) {
+	return computeSharedSecret(senderPrivateKeyEncoded, requesterPublicKeyEncoded, curve, false)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         ComputeSharedSecretX25519                          */
+/* -------------------------------------------------------------------------- */
+// returns the shared secret base64 encoded like ComputeSharedSecret, but
+// computes it with NewX25519ECDH's constant-time X25519. The result is the
+// same for public keys in the prime-order subgroup, which covers all keys
+// made by keypairgen.
+//
+// Like ComputeSharedSecret, the returned string can't be wiped; prefer
+// NewX25519ECDH and its SharedSecret method.
+func ComputeSharedSecretX25519(senderPrivateKeyEncoded, requesterPublicKeyEncoded string, curve *Curve) (string, error) {
+	return computeSharedSecret(senderPrivateKeyEncoded, requesterPublicKeyEncoded, curve, true)
+}
+
+func computeSharedSecret(senderPrivateKeyEncoded, requesterPublicKeyEncoded string, curve *Curve, x25519 bool) (string, error) {
 	// Decode the private key
-	senderPrivateKey, err := DecodeBase64ToPrivateKey(senderPrivateKeyEncoded)
+	senderKey, destroy, err := NewECDHKey(curve, senderPrivateKeyEncoded, x25519)
 	if err != nil {
 		return "", err
 	}
+	defer destroy()
 
 	// Decode the public key
 	requesterPublicKey, err := DecodeBase64ToPublicKey(requesterPublicKeyEncoded, curve)
@@ -23,40 +46,44 @@ func ComputeSharedSecret(senderPrivateKeyEncoded, requesterPublicKeyEncoded stri
 	}
 
 	// Compute the shared secret: (privateKey * publicKey).X
-	sharedSecretPoint := requesterPublicKey.ScalarMul(senderPrivateKey)
-	return EncodePrivateKeyToBase64(sharedSecretPoint.X), nil
+	sharedSecret, err := senderKey.SharedSecret(requesterPublicKey)
+	if err != nil {
+		return "", err
+	}
+	defer sharedSecret.Destroy()
+	return EncodeBase64(sharedSecret.Bytes()), nil
 }
 
 func DecodeBase64ToPrivateKey(encodedKey string) (*big.Int, error) {
 	keyBytes, err := base64.StdEncoding.DecodeString(encodedKey)
 	if err != nil {
-		return nil, err
+		return nil, WithKind(ErrInvalidKey, err)
 	}
+	defer Zeroize(keyBytes)
 	return new(big.Int).SetBytes(keyBytes), nil
 }
 
 func DecodeBase64ToPublicKey(encodedKey string, curve *Curve) (*Point, error) {
 	keyBytes, err := base64.StdEncoding.DecodeString(encodedKey)
 	if err != nil {
-		return nil, err
+		return nil, WithKind(ErrInvalidKey, err)
 	}
+	return DecodePublicKey(keyBytes, curve)
+}
 
-	var xBytes, yBytes []byte
-	if len(keyBytes) == 65 {
-		// Ensure the key is in uncompressed form (starts with 0x04)
-		if keyBytes[0] != 0x04 {
-			return nil, errors.New("invalid public key format")
-		}
-		xBytes = keyBytes[1:33] // 32 bytes for x-coordinate
-		yBytes = keyBytes[33:]  // 32 bytes for y-coordinate
-	} else {
-		// Assumes x509 format
-		xBytes = keyBytes[len(keyBytes)-64 : len(keyBytes)-32]
-		yBytes = keyBytes[len(keyBytes)-32:]
+/* -------------------------------------------------------------------------- */
+/*                               DecodePublicKey                              */
+/* -------------------------------------------------------------------------- */
+// decodes a public key on curve given as an uncompressed point or as a DER
+// encoded X.509 SubjectPublicKeyInfo.
+func DecodePublicKey(keyBytes []byte, curve *Curve) (*Point, error) {
+	if curve == nil {
+		return nil, errors.New("curve cannot be nil")
 	}
 
-	x := new(big.Int).SetBytes(xBytes)
-	y := new(big.Int).SetBytes(yBytes)
-	point, err := NewPoint(x, y, curve)
-	return point, err
+	// Uncompressed point (0x04 || X || Y), otherwise X.509
+	if len(keyBytes) == 1+2*curve.CoordinateSize() {
+		return decodeUncompressedPoint(keyBytes, curve)
+	}
+	return ParseX509PublicKey(keyBytes, curve)
 }
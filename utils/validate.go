@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidCurve is wrapped by every error returned from Curve.Validate.
+var ErrInvalidCurve = errors.New("invalid curve")
+
+// primalityRounds is the number of Miller-Rabin rounds used on p and q, on
+// top of the Baillie-PSW test ProbablyPrime always runs.
+const primalityRounds = 20
+
+/* -------------------------------------------------------------------------- */
+/*                                  Validate                                  */
+/* -------------------------------------------------------------------------- */
+// checks that the curve parameters describe a usable elliptic curve group:
+//   - p is an odd prime and a, b are reduced mod p
+//   - the curve is non-singular: 4a³ + 27b² ≠ 0 (mod p)
+//   - the base point G lies on the curve
+//   - q is prime and q·G is the point at infinity
+//   - the cofactor is determined by the Hasse bound (see Cofactor)
+//   - an OID is well formed and, if it names a built-in curve, the
+//     parameters are that curve's
+//
+// Register runs Validate on every curve it adds.
+func (c *Curve) Validate() error {
+	if c.P == nil || c.A == nil || c.B == nil || c.Q == nil || c.Gx == nil || c.Gy == nil {
+		return fmt.Errorf("%w: missing parameters", ErrInvalidCurve)
+	}
+	if c.P.Cmp(big.NewInt(3)) <= 0 || !c.P.ProbablyPrime(primalityRounds) {
+		return fmt.Errorf("%w: p is not an odd prime", ErrInvalidCurve)
+	}
+	for name, v := range map[string]*big.Int{"a": c.A, "b": c.B, "Gx": c.Gx, "Gy": c.Gy} {
+		if v.Sign() < 0 || v.Cmp(c.P) >= 0 {
+			return fmt.Errorf("%w: %s is not in [0, p)", ErrInvalidCurve, name)
+		}
+	}
+
+	// 4a³ + 27b² mod p
+	discriminant := new(big.Int).Exp(c.A, big.NewInt(3), c.P)
+	discriminant.Mul(discriminant, big.NewInt(4))
+	b2 := new(big.Int).Exp(c.B, big.NewInt(2), c.P)
+	discriminant.Add(discriminant, b2.Mul(b2, big.NewInt(27)))
+	if discriminant.Mod(discriminant, c.P).Sign() == 0 {
+		return fmt.Errorf("%w: curve is singular (4a³ + 27b² = 0 mod p)", ErrInvalidCurve)
+	}
+
+	if !c.IsPointOnCurve(c.Gx, c.Gy) {
+		return fmt.Errorf("%w: base point is not on the curve", ErrInvalidCurve)
+	}
+	if !c.Q.ProbablyPrime(primalityRounds) {
+		return fmt.Errorf("%w: q is not prime", ErrInvalidCurve)
+	}
+	if _, err := c.Cofactor(); err != nil {
+		return err
+	}
+	base := &Point{X: c.Gx, Y: c.Gy, Curve: c}
+	if base.ScalarMul(c.Q) != IdentityPoint {
+		return fmt.Errorf("%w: q·G is not the point at infinity", ErrInvalidCurve)
+	}
+
+	return c.validateOID()
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                  Cofactor                                  */
+/* -------------------------------------------------------------------------- */
+// returns the cofactor h = #E / q. By Hasse's theorem #E lies within
+// p + 1 ± 2√p, so when q > 4√p exactly one multiple of q falls in that
+// interval and h is determined without counting points.
+func (c *Curve) Cofactor() (*big.Int, error) {
+	if c.P == nil || c.Q == nil || c.Q.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: missing parameters", ErrInvalidCurve)
+	}
+	// 2√p, rounded up
+	twoSqrtP := new(big.Int).Sqrt(new(big.Int).Lsh(c.P, 2))
+	twoSqrtP.Add(twoSqrtP, big.NewInt(1))
+	if new(big.Int).Lsh(twoSqrtP, 1).Cmp(c.Q) >= 0 {
+		return nil, fmt.Errorf("%w: q is too small for the cofactor to follow from the Hasse bound", ErrInvalidCurve)
+	}
+
+	pPlus1 := new(big.Int).Add(c.P, big.NewInt(1))
+	low := new(big.Int).Sub(pPlus1, twoSqrtP)
+	high := new(big.Int).Add(pPlus1, twoSqrtP)
+
+	// The smallest multiple of q that is >= low
+	h := new(big.Int).Add(low, new(big.Int).Sub(c.Q, big.NewInt(1)))
+	h.Div(h, c.Q)
+	if h.Sign() <= 0 || new(big.Int).Mul(h, c.Q).Cmp(high) > 0 {
+		return nil, fmt.Errorf("%w: no multiple of q satisfies the Hasse bound", ErrInvalidCurve)
+	}
+	return h, nil
+}
+
+// validateOID checks that the OID is well formed and that a built-in
+// curve's OID is only used with that curve's parameters.
+func (c *Curve) validateOID() error {
+	if len(c.OID) == 0 {
+		return nil
+	}
+	if _, err := objectIdentifier(c.OID); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCurve, err)
+	}
+	for _, constructor := range builtinCurves {
+		builtin, err := constructor()
+		if err != nil || builtin == c || string(builtin.OID) != string(c.OID) {
+			continue
+		}
+		if !sameCurve(builtin, c) || builtin.Q.Cmp(c.Q) != 0 ||
+			builtin.Gx.Cmp(c.Gx) != 0 || builtin.Gy.Cmp(c.Gy) != 0 {
+			return fmt.Errorf("%w: OID belongs to %s but the parameters differ", ErrInvalidCurve, builtin.Name)
+		}
+	}
+	return nil
+}
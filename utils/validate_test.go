@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for Curve.Validate                          */
+/* -------------------------------------------------------------------------- */
+func TestValidateBuiltins(t *testing.T) {
+	cofactors := map[string]int64{"BC25519": 8, "P-256": 1, "P-384": 1, "secp256k1": 1}
+	for _, curve := range Curves() {
+		if _, builtin := cofactors[curve.Name]; !builtin {
+			continue
+		}
+		assert.NoError(t, curve.Validate(), curve.Name)
+		h, err := curve.Cofactor()
+		assert.NoError(t, err)
+		assert.Equal(t, cofactors[curve.Name], h.Int64(), curve.Name)
+	}
+}
+
+func TestValidateRejects(t *testing.T) {
+	p256, err := GetP256Curve()
+	assert.NoError(t, err)
+	bc25519Curve, err := GetBC25519Curve()
+	assert.NoError(t, err)
+
+	// nextPrime returns the smallest prime above n.
+	nextPrime := func(n *big.Int) *big.Int {
+		candidate := new(big.Int).Add(n, big.NewInt(1))
+		for !candidate.ProbablyPrime(primalityRounds) {
+			candidate.Add(candidate, big.NewInt(1))
+		}
+		return candidate
+	}
+
+	tests := []struct {
+		name   string
+		modify func(c *Curve)
+		reason string
+	}{
+		{"composite p", func(c *Curve) { c.P = new(big.Int).Add(c.P, big.NewInt(1)) }, "p is not an odd prime"},
+		{"unreduced a", func(c *Curve) { c.A = new(big.Int).Add(c.A, c.P) }, "a is not in [0, p)"},
+		{"singular", func(c *Curve) { c.A, c.B = big.NewInt(0), big.NewInt(0) }, "singular"},
+		{"base point off curve", func(c *Curve) { c.Gy = new(big.Int).Add(c.Gy, big.NewInt(1)) }, "base point is not on the curve"},
+		{"composite q", func(c *Curve) { c.Q = new(big.Int).Add(c.Q, big.NewInt(1)) }, "q is not prime"},
+		{"q too small", func(c *Curve) { c.Q = big.NewInt(3) }, "too small"},
+		{"wrong order", func(c *Curve) { c.Q = nextPrime(c.Q) }, "q·G is not the point at infinity"},
+		{"OID of another curve", func(c *Curve) { c.OID = bc25519Curve.OID }, "OID belongs to BC25519"},
+		{"malformed OID", func(c *Curve) { c.OID = []byte{0x01, 0x83} }, "truncated curve OID"},
+		{"missing parameter", func(c *Curve) { c.Gx = nil }, "missing parameters"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			curve := *p256
+			curve.Name = "test-" + test.name
+			test.modify(&curve)
+			err := curve.Validate()
+			assert.ErrorIs(t, err, ErrInvalidCurve)
+			assert.ErrorContains(t, err, test.reason)
+
+			// Register refuses it
+			assert.ErrorIs(t, Register(&curve), ErrInvalidCurve)
+			_, err = LookupByName(curve.Name)
+			assert.ErrorIs(t, err, ErrCurveNotFound)
+		})
+	}
+}
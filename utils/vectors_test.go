@@ -0,0 +1,141 @@
+package utils
+
+import (
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/internal/testvectors"
+)
+
+/* -------------------------------------------------------------------------- */
+/*              Known-answer tests for SharedSecret and Sha256HKDF            */
+/* -------------------------------------------------------------------------- */
+func TestSharedSecretVectors(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+	vectors, err := testvectors.Load()
+	assert.NoError(t, err)
+	assert.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			/* ------------------------------ Shared secret ----------------------------- */
+			requesterKey, err := NewPrivateKeyECDH(BC25519, v.RequesterPrivateKey)
+			assert.NoError(t, err)
+			senderPublicKey, err := DecodeBase64ToPublicKey(v.SenderPublicKey, BC25519)
+			assert.NoError(t, err)
+			sharedSecret, err := requesterKey.SharedSecret(senderPublicKey)
+			assert.NoError(t, err)
+			assert.Len(t, sharedSecret.Bytes(), 32)
+			assert.Equal(t, v.SharedSecret, EncodeBase64(sharedSecret.Bytes()))
+
+			// Both sides must agree
+			if v.SenderPrivateKey != "" {
+				computed, err := ComputeSharedSecret(v.SenderPrivateKey, v.RequesterPublicKey, BC25519)
+				assert.NoError(t, err)
+				assert.Equal(t, v.SharedSecret, computed)
+				computed, err = ComputeSharedSecretX25519(v.SenderPrivateKey, v.RequesterPublicKey, BC25519)
+				assert.NoError(t, err)
+				assert.Equal(t, v.SharedSecret, computed)
+
+				// The public keys must match the private keys
+				senderKey, err := NewPrivateKeyECDH(BC25519, v.SenderPrivateKey)
+				assert.NoError(t, err)
+				publicKey, err := senderKey.PublicKey()
+				assert.NoError(t, err)
+				assert.Equal(t, 0, publicKey.X.Cmp(senderPublicKey.X))
+				assert.Equal(t, 0, publicKey.Y.Cmp(senderPublicKey.Y))
+			}
+
+			/* ------------------------------- Sha256HKDF ------------------------------- */
+			senderNonce, err := base64.StdEncoding.DecodeString(v.SenderNonce)
+			assert.NoError(t, err)
+			requesterNonce, err := base64.StdEncoding.DecodeString(v.RequesterNonce)
+			assert.NoError(t, err)
+			xorOfNonces, err := XORBytes(senderNonce, requesterNonce)
+			assert.NoError(t, err)
+			aesKey, err := Sha256HKDF(xorOfNonces[:20], sharedSecret, 32)
+			assert.NoError(t, err)
+			assert.Equal(t, v.AESKey, EncodeBase64(aesKey.Bytes()))
+		})
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                     Tests for short coordinate encodings                   */
+/* -------------------------------------------------------------------------- */
+func TestShortCoordinateEncoding(t *testing.T) {
+	vectors, err := testvectors.Load()
+	assert.NoError(t, err)
+	for _, v := range vectors {
+		switch v.Name {
+		case "short-shared-secret":
+			sharedSecret, err := base64.StdEncoding.DecodeString(v.SharedSecret)
+			assert.NoError(t, err)
+			assert.Len(t, sharedSecret, 32)
+			assert.Equal(t, byte(0), sharedSecret[0])
+		case "abdm-seed", "short-public-key-coordinate":
+			publicKey, err := base64.StdEncoding.DecodeString(v.SenderPublicKey)
+			assert.NoError(t, err)
+			assert.Len(t, publicKey, 65)
+		}
+	}
+}
+
+/* -------------------------------------------------------------------------- */
+/*                        Tests for public key padding                        */
+/* -------------------------------------------------------------------------- */
+// Coordinates with leading zero bytes are left-padded, so encoded BC25519 keys
+// always have the fixed 65-byte uncompressed length.
+func TestPublicKeyPadding(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+
+	// find a public key whose X coordinate is shorter than 32 bytes
+	var x, y *big.Int
+	for k := int64(1); ; k++ {
+		x, y, err = GeneratePublicKey(BC25519, big.NewInt(k))
+		assert.NoError(t, err)
+		if len(x.Bytes()) < 32 {
+			break
+		}
+	}
+
+	encoded, err := base64.StdEncoding.DecodeString(EncodePublicKeyToBase64(x, y))
+	assert.NoError(t, err)
+	assert.Len(t, encoded, 65)
+	assert.Equal(t, byte(0x04), encoded[0])
+	assert.Equal(t, byte(0x00), encoded[1])
+	point, err := DecodePublicKey(encoded, BC25519)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, point.X.Cmp(x))
+
+	x509Encoded, err := EncodeX509PublicKeyToBase64(x, y)
+	assert.NoError(t, err)
+	der, err := base64.StdEncoding.DecodeString(x509Encoded)
+	assert.NoError(t, err)
+	assert.Equal(t, encoded, der[len(der)-65:])
+	point, err = ParseX509PublicKey(der, BC25519)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, point.X.Cmp(x))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                         Tests for vector provenance                        */
+/* -------------------------------------------------------------------------- */
+// Every vector must say where it came from, and the corpus must keep at least
+// one vector this implementation did not produce.
+func TestVectorSources(t *testing.T) {
+	vectors, err := testvectors.Load()
+	assert.NoError(t, err)
+	external := 0
+	for _, v := range vectors {
+		assert.Contains(t, []string{testvectors.SourceExternal, testvectors.SourceSelfGenerated}, v.Source, v.Name)
+		if v.Source == testvectors.SourceExternal {
+			external++
+		}
+	}
+	assert.NotZero(t, external)
+}
@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"crypto/ecdh"
+	"errors"
+	"math/big"
+	"sync"
+)
+
+// BC25519 is Curve25519 in short Weierstrass form. A point (x, y) maps to the
+// Montgomery point (u, v) of y² = x³ + 486662x² + x by u = x - A/3 and v = y,
+// where A = 486662, so X25519 u-coordinates and BC25519 x-coordinates differ
+// by a constant.
+
+// montgomeryA is the Curve25519 Montgomery coefficient A.
+var montgomeryA = big.NewInt(486662)
+
+// x25519Size is the length of X25519 keys and u-coordinates.
+const x25519Size = 32
+
+/* -------------------------------------------------------------------------- */
+/*                           WeierstrassToMontgomery                          */
+/* -------------------------------------------------------------------------- */
+// returns the X25519 u-coordinate of a BC25519 point as 32 little-endian
+// bytes, the encoding crypto/ecdh uses for X25519 public keys.
+func WeierstrassToMontgomery(point *Point) ([]byte, error) {
+	if point == nil || point == IdentityPoint || !isBC25519(point.Curve) {
+		return nil, WithKind(ErrInvalidKey, errors.New("point is not on BC25519"))
+	}
+	u := new(big.Int).Sub(point.X, aOver3())
+	u.Mod(u, point.Curve.P)
+	return reverse(u.FillBytes(make([]byte, x25519Size))), nil
+}
+
+/* -------------------------------------------------------------------------- */
+/*                           MontgomeryToWeierstrass                          */
+/* -------------------------------------------------------------------------- */
+// returns the BC25519 point for a little-endian X25519 u-coordinate. The
+// u-coordinate doesn't fix the sign of y, so either of the two points with
+// that x-coordinate may be returned; both give the same ECDH shared secret.
+// u-coordinates on the quadratic twist are rejected.
+func MontgomeryToWeierstrass(u []byte, curve *Curve) (*Point, error) {
+	if len(u) != x25519Size {
+		return nil, WithKind(ErrInvalidKey, errors.New("X25519 u-coordinate must be 32 bytes"))
+	}
+	if !isBC25519(curve) {
+		return nil, errors.New("curve is not BC25519")
+	}
+	// RFC 7748 ignores the top bit of the u-coordinate
+	le := append([]byte(nil), u...)
+	le[x25519Size-1] &= 0x7f
+	x := new(big.Int).SetBytes(reverse(le))
+	x.Add(x, aOver3())
+	x.Mod(x, curve.P)
+	y := new(big.Int).ModSqrt(curve.Evaluate(x), curve.P)
+	if y == nil {
+		return nil, WithKind(ErrInvalidKey, errors.New("u-coordinate is not on the curve"))
+	}
+	point, err := NewPoint(x, y, curve)
+	return point, WithKind(ErrInvalidKey, err)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             PrivateKeyToX25519                             */
+/* -------------------------------------------------------------------------- */
+// returns an X25519 private key that acts like the BC25519 scalar
+// privateKey on the prime-order subgroup.
+//
+// X25519 clamps scalars to 2^254 + 8t with 0 <= t < 2^251. Since a point P of
+// order q satisfies kP = (k mod q)P and -kP has the same x-coordinate, it is
+// enough to find such a scalar congruent to ±privateKey mod q; one exists for
+// all but a negligible fraction of keys. Points with a small-order component,
+// which honest key generation never produces, give a different result than
+// the BC25519 arithmetic because clamping clears the cofactor.
+func PrivateKeyToX25519(curve *Curve, privateKey *big.Int) (*ecdh.PrivateKey, error) {
+	if !isBC25519(curve) {
+		return nil, errors.New("curve is not BC25519")
+	}
+	if privateKey == nil || privateKey.Sign() <= 0 || privateKey.Cmp(curve.Q) >= 0 {
+		return nil, WithKind(ErrInvalidKey, errors.New("invalid private key"))
+	}
+	base := new(big.Int).Lsh(big.NewInt(1), 254)
+	limit := new(big.Int).Lsh(big.NewInt(1), 251)
+	inv8 := new(big.Int).ModInverse(big.NewInt(8), curve.Q)
+
+	for _, sign := range []int64{1, -1} {
+		// t = (±privateKey - 2^254) / 8 mod q
+		t := new(big.Int).Mul(privateKey, big.NewInt(sign))
+		t.Sub(t, base)
+		t.Mul(t, inv8)
+		t.Mod(t, curve.Q)
+		if t.Cmp(limit) >= 0 {
+			ZeroizeBigInt(t)
+			continue
+		}
+		scalar := t.Lsh(t, 3)
+		scalar.Add(scalar, base)
+		b := reverse(scalar.FillBytes(make([]byte, x25519Size)))
+		ZeroizeBigInt(scalar)
+		key, err := ecdh.X25519().NewPrivateKey(b)
+		Zeroize(b)
+		return key, err
+	}
+	return nil, WithKind(ErrInvalidKey, errors.New("private key has no X25519 equivalent"))
+}
+
+type x25519ECDH struct {
+	*privateKeyECDH
+	key *ecdh.PrivateKey
+}
+
+/* -------------------------------------------------------------------------- */
+/*                                NewX25519ECDH                               */
+/* -------------------------------------------------------------------------- */
+// wraps a base64 encoded BC25519 private key like NewPrivateKeyECDH, but
+// computes shared secrets with crypto/ecdh's constant-time X25519. Shared
+// secrets are identical to NewPrivateKeyECDH's for peer keys in the
+// prime-order subgroup, which covers all keys made by keypairgen.
+//
+// crypto/ecdh keeps its own copy of the scalar, which Destroy can't wipe.
+func NewX25519ECDH(curve *Curve, encodedPrivateKey string) (*x25519ECDH, error) {
+	privateKey, err := NewPrivateKeyECDH(curve, encodedPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := PrivateKeyToX25519(curve, privateKey.privateKey)
+	if err != nil {
+		privateKey.Destroy()
+		return nil, err
+	}
+	return &x25519ECDH{privateKeyECDH: privateKey, key: key}, nil
+}
+
+func (k *x25519ECDH) SharedSecret(peerPublicKey *Point) (*Secret, error) {
+	if peerPublicKey == nil || !sameCurve(peerPublicKey.Curve, k.curve) {
+		return nil, WithKind(ErrInvalidKey, errors.New("peer public key is not on the key's curve"))
+	}
+	if !k.curve.IsPointOnCurve(peerPublicKey.X, peerPublicKey.Y) {
+		return nil, WithKind(ErrInvalidKey, errors.New("peer public key is not on the curve"))
+	}
+	if k.privateKey.Sign() <= 0 {
+		return nil, errors.New("private key has been destroyed")
+	}
+	u, err := WeierstrassToMontgomery(peerPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	peer, err := ecdh.X25519().NewPublicKey(u)
+	if err != nil {
+		return nil, WithKind(ErrInvalidKey, err)
+	}
+	sharedU, err := k.key.ECDH(peer)
+	if err != nil {
+		// crypto/ecdh rejects low-order points, whose result is all zeros
+		return nil, WithKind(ErrInvalidKey, errors.New("shared secret is the point at infinity"))
+	}
+	defer Zeroize(sharedU)
+
+	x := new(big.Int).SetBytes(reverse(sharedU))
+	defer ZeroizeBigInt(x)
+	x.Add(x, aOver3())
+	x.Mod(x, k.curve.P)
+	return NewSecret(x.FillBytes(make([]byte, coordinateSize))), nil
+}
+
+var (
+	aOver3Once sync.Once
+	aOver3P    *big.Int
+)
+
+// aOver3 returns A/3 mod p for BC25519.
+func aOver3() *big.Int {
+	aOver3Once.Do(func() {
+		curve, _ := GetBC25519Curve()
+		aOver3P = new(big.Int).Mul(montgomeryA, new(big.Int).ModInverse(big.NewInt(3), curve.P))
+		aOver3P.Mod(aOver3P, curve.P)
+	})
+	return aOver3P
+}
+
+// isBC25519 reports whether curve has the BC25519 parameters.
+func isBC25519(curve *Curve) bool {
+	bc25519Curve, _ := GetBC25519Curve()
+	return sameCurve(curve, bc25519Curve)
+}
+
+// reverse reverses b in place and returns it, converting between big- and
+// little-endian.
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
@@ -0,0 +1,185 @@
+package utils
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zoop/fidelius-go/internal/testvectors"
+)
+
+/* -------------------------------------------------------------------------- */
+/*                     Tests for the Montgomery conversion                    */
+/* -------------------------------------------------------------------------- */
+func TestMontgomeryConversion(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+
+	// The BC25519 base point is the X25519 base point u = 9
+	base, err := NewPoint(BC25519.Gx, BC25519.Gy, BC25519)
+	assert.NoError(t, err)
+	u, err := WeierstrassToMontgomery(base)
+	assert.NoError(t, err)
+	nine := make([]byte, 32)
+	nine[0] = 9
+	assert.Equal(t, nine, u)
+
+	back, err := MontgomeryToWeierstrass(u, BC25519)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, back.X.Cmp(BC25519.Gx))
+
+	// u-coordinates on the twist have no BC25519 point
+	for i := byte(2); ; i++ {
+		u := make([]byte, 32)
+		u[0] = i
+		x := new(big.Int).Add(big.NewInt(int64(i)), aOver3())
+		if new(big.Int).ModSqrt(BC25519.Evaluate(x), BC25519.P) == nil {
+			_, err := MontgomeryToWeierstrass(u, BC25519)
+			assert.ErrorIs(t, err, ErrInvalidKey)
+			break
+		}
+	}
+
+	_, err = MontgomeryToWeierstrass(make([]byte, 31), BC25519)
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                    Differential tests for X25519 ECDH                      */
+/* -------------------------------------------------------------------------- */
+func TestX25519MatchesBC25519(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+
+	type pair struct{ privateKey, publicKey string }
+	var pairs []pair
+	for i := 0; i < 32; i++ {
+		privateKey, err := GeneratePrivateKey(BC25519)
+		assert.NoError(t, err)
+		peer, err := GeneratePrivateKey(BC25519)
+		assert.NoError(t, err)
+		x, y, err := GeneratePublicKey(BC25519, peer)
+		assert.NoError(t, err)
+		pairs = append(pairs, pair{EncodePrivateKeyToBase64(privateKey), EncodePublicKeyToBase64(x, y)})
+	}
+	// Edge scalars and the known-answer vectors
+	for _, d := range []*big.Int{big.NewInt(1), big.NewInt(2), new(big.Int).Sub(BC25519.Q, big.NewInt(1))} {
+		pairs = append(pairs, pair{EncodePrivateKeyToBase64(d), EncodePublicKeyToBase64(BC25519.Gx, BC25519.Gy)})
+	}
+	vectors, err := testvectors.Load()
+	assert.NoError(t, err)
+	for _, v := range vectors {
+		pairs = append(pairs, pair{v.RequesterPrivateKey, v.SenderPublicKey})
+	}
+
+	for _, p := range pairs {
+		peer, err := DecodeBase64ToPublicKey(p.publicKey, BC25519)
+		assert.NoError(t, err)
+
+		reference, err := NewPrivateKeyECDH(BC25519, p.privateKey)
+		assert.NoError(t, err)
+		want, err := reference.SharedSecret(peer)
+		assert.NoError(t, err)
+
+		key, err := NewX25519ECDH(BC25519, p.privateKey)
+		assert.NoError(t, err)
+		got, err := key.SharedSecret(peer)
+		assert.NoError(t, err)
+		assert.Equal(t, want.Bytes(), got.Bytes())
+
+		// The public key is unchanged too
+		wantPublic, err := reference.PublicKey()
+		assert.NoError(t, err)
+		gotPublic, err := key.PublicKey()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, wantPublic.Y.Cmp(gotPublic.Y))
+	}
+}
+
+func TestX25519Interop(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+
+	// A BC25519 key pair and a native crypto/ecdh X25519 key pair
+	privateKey, err := GeneratePrivateKey(BC25519)
+	assert.NoError(t, err)
+	x, y, err := GeneratePublicKey(BC25519, privateKey)
+	assert.NoError(t, err)
+	bcPublic := &Point{X: x, Y: y, Curve: BC25519}
+	native, err := ecdh.X25519().GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	// The converted BC25519 key is the X25519 public key of the converted scalar
+	converted, err := PrivateKeyToX25519(BC25519, privateKey)
+	assert.NoError(t, err)
+	u, err := WeierstrassToMontgomery(bcPublic)
+	assert.NoError(t, err)
+	assert.Equal(t, converted.PublicKey().Bytes(), u)
+
+	// Native side: X25519 with the converted BC25519 public key
+	bcAsX25519, err := ecdh.X25519().NewPublicKey(u)
+	assert.NoError(t, err)
+	nativeSecret, err := native.ECDH(bcAsX25519)
+	assert.NoError(t, err)
+
+	// BC25519 side: ECDH with the converted native public key
+	nativeAsBC25519, err := MontgomeryToWeierstrass(native.PublicKey().Bytes(), BC25519)
+	assert.NoError(t, err)
+	bcKey, err := NewPrivateKeyECDH(BC25519, EncodePrivateKeyToBase64(privateKey))
+	assert.NoError(t, err)
+	bcSecret, err := bcKey.SharedSecret(nativeAsBC25519)
+	assert.NoError(t, err)
+
+	// u = x - A/3
+	sharedU := new(big.Int).Sub(new(big.Int).SetBytes(bcSecret.Bytes()), aOver3())
+	sharedU.Mod(sharedU, BC25519.P)
+	assert.Equal(t, nativeSecret, reverse(sharedU.FillBytes(make([]byte, 32))))
+}
+
+/* -------------------------------------------------------------------------- */
+/*                            Tests for NewECDHKey                            */
+/* -------------------------------------------------------------------------- */
+func TestNewECDHKey(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+	privateKey, err := GeneratePrivateKey(BC25519)
+	assert.NoError(t, err)
+	encoded := EncodePrivateKeyToBase64(privateKey)
+
+	key, destroy, err := NewECDHKey(BC25519, encoded, false)
+	assert.NoError(t, err)
+	assert.IsType(t, &privateKeyECDH{}, key)
+	destroy()
+	assert.Zero(t, key.(*privateKeyECDH).privateKey.Sign())
+
+	key, destroy, err = NewECDHKey(BC25519, encoded, true)
+	assert.NoError(t, err)
+	assert.IsType(t, &x25519ECDH{}, key)
+	destroy()
+	assert.Zero(t, key.(*x25519ECDH).privateKeyECDH.privateKey.Sign())
+
+	_, _, err = NewECDHKey(BC25519, "", false)
+	assert.Error(t, err)
+	_, _, err = NewECDHKey(BC25519, "", true)
+	assert.Error(t, err)
+}
+
+/* -------------------------------------------------------------------------- */
+/*                          Tests for GenerateECDHKey                         */
+/* -------------------------------------------------------------------------- */
+func TestGenerateECDHKey(t *testing.T) {
+	BC25519, err := GetBC25519Curve()
+	assert.NoError(t, err)
+	key, err := GenerateECDHKey(BC25519)
+	assert.NoError(t, err)
+	publicKey, err := key.PublicKey()
+	assert.NoError(t, err)
+	assert.True(t, BC25519.IsPointOnCurve(publicKey.X, publicKey.Y))
+
+	key.Destroy()
+	assert.Zero(t, key.privateKey.Sign())
+	_, err = GenerateECDHKey(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"encoding/asn1"
+	"errors"
+	"math/big"
+)
+
+// oidECPublicKey is id-ecPublicKey from RFC 5480.
+var oidECPublicKey = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// subjectPublicKeyInfo is the X.509 SubjectPublicKeyInfo structure. The
+// curve parameters are kept raw: Bouncy Castle writes BC25519 keys with
+// explicit parameters rather than a named curve.
+type subjectPublicKeyInfo struct {
+	Algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.RawValue `asn1:"optional"`
+	}
+	PublicKey asn1.BitString
+}
+
+// ecParameters is the explicit form of the curve parameters (SEC 1, C.2).
+type ecParameters struct {
+	Version int
+	FieldID struct {
+		FieldType asn1.ObjectIdentifier
+		Prime     *big.Int
+	}
+	Curve struct {
+		A    []byte
+		B    []byte
+		Seed asn1.BitString `asn1:"optional"`
+	}
+	Base     []byte
+	Order    *big.Int
+	Cofactor *big.Int `asn1:"optional"`
+}
+
+/* -------------------------------------------------------------------------- */
+/*                             ParseX509PublicKey                             */
+/* -------------------------------------------------------------------------- */
+// parses a DER encoded X.509 SubjectPublicKeyInfo holding an uncompressed EC
+// point on curve. The curve may be given by its OID or by explicit
+// parameters, which must match curve.
+func ParseX509PublicKey(der []byte, curve *Curve) (*Point, error) {
+	var spki subjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, WithKind(ErrInvalidKey, err)
+	}
+	if len(rest) > 0 {
+		return nil, WithKind(ErrInvalidKey, errors.New("trailing data after X.509 public key"))
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidECPublicKey) {
+		return nil, WithKind(ErrInvalidKey, errors.New("X.509 public key is not an EC key"))
+	}
+	if err := checkX509Parameters(spki.Algorithm.Parameters, curve); err != nil {
+		return nil, WithKind(ErrInvalidKey, err)
+	}
+	if spki.PublicKey.BitLength%8 != 0 {
+		return nil, WithKind(ErrInvalidKey, errors.New("invalid X.509 public key bit string"))
+	}
+	return decodeUncompressedPoint(spki.PublicKey.Bytes, curve)
+}
+
+// checkX509Parameters checks that the AlgorithmIdentifier parameters name
+// curve.
+func checkX509Parameters(params asn1.RawValue, curve *Curve) error {
+	switch {
+	case params.Class == asn1.ClassUniversal && params.Tag == asn1.TagOID:
+		var oid asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(params.FullBytes, &oid); err != nil {
+			return err
+		}
+		curveOID, err := objectIdentifier(curve.OID)
+		if err != nil || !oid.Equal(curveOID) {
+			return errors.New("X.509 public key is for a different curve")
+		}
+		return nil
+	case params.Class == asn1.ClassUniversal && params.Tag == asn1.TagSequence:
+		var explicit ecParameters
+		if _, err := asn1.Unmarshal(params.FullBytes, &explicit); err != nil {
+			return err
+		}
+		if explicit.FieldID.Prime == nil || explicit.Order == nil ||
+			explicit.FieldID.Prime.Cmp(curve.P) != 0 || explicit.Order.Cmp(curve.Q) != 0 ||
+			new(big.Int).SetBytes(explicit.Curve.A).Cmp(curve.A) != 0 ||
+			new(big.Int).SetBytes(explicit.Curve.B).Cmp(curve.B) != 0 {
+			return errors.New("X.509 public key is for a different curve")
+		}
+		return nil
+	}
+	return errors.New("X.509 public key has no curve parameters")
+}
+
+// marshalX509PublicKey encodes an uncompressed point as a SubjectPublicKeyInfo
+// naming curve by its OID.
+func marshalX509PublicKey(curve *Curve, point []byte) ([]byte, error) {
+	oid, err := objectIdentifier(curve.OID)
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(oid)
+	if err != nil {
+		return nil, err
+	}
+	var spki subjectPublicKeyInfo
+	spki.Algorithm.Algorithm = oidECPublicKey
+	spki.Algorithm.Parameters = asn1.RawValue{FullBytes: params}
+	spki.PublicKey = asn1.BitString{Bytes: point, BitLength: 8 * len(point)}
+	return asn1.Marshal(spki)
+}
+
+// objectIdentifier decodes a curve OID. Curve OIDs are stored as one
+// base-128 group per arc, without DER's merging of the first two arcs.
+func objectIdentifier(b []byte) (asn1.ObjectIdentifier, error) {
+	var oid asn1.ObjectIdentifier
+	arc := 0
+	for i, c := range b {
+		if arc > 1<<24 {
+			return nil, errors.New("curve OID arc is too large")
+		}
+		arc = arc<<7 | int(c&0x7f)
+		if c&0x80 == 0 {
+			oid = append(oid, arc)
+			arc = 0
+		} else if i == len(b)-1 {
+			return nil, errors.New("truncated curve OID")
+		}
+	}
+	if len(oid) < 2 {
+		return nil, errors.New("curve has no OID")
+	}
+	return oid, nil
+}
+
+// decodeUncompressedPoint decodes 0x04 || X || Y.
+func decodeUncompressedPoint(b []byte, curve *Curve) (*Point, error) {
+	size := curve.CoordinateSize()
+	if len(b) != 1+2*size || b[0] != 0x04 {
+		return nil, WithKind(ErrInvalidKey, errors.New("invalid public key format"))
+	}
+	x := new(big.Int).SetBytes(b[1 : 1+size])
+	y := new(big.Int).SetBytes(b[1+size:])
+	point, err := NewPoint(x, y, curve)
+	return point, WithKind(ErrInvalidKey, err)
+}